@@ -1,82 +1,181 @@
-package main
-
-import (
-	"context"
-	"log"
-	"net"
-	"os"
-	"time"
-
-	"github.com/arthurhzna/Golang_gRPC/internal/grpcmiddlerware"
-	"github.com/arthurhzna/Golang_gRPC/internal/handler"
-	"github.com/arthurhzna/Golang_gRPC/internal/repository"
-	"github.com/arthurhzna/Golang_gRPC/internal/service"
-	"github.com/arthurhzna/Golang_gRPC/pb/auth"
-	"github.com/arthurhzna/Golang_gRPC/pb/cart"
-	"github.com/arthurhzna/Golang_gRPC/pb/newsletter"
-	"github.com/arthurhzna/Golang_gRPC/pb/order"
-	"github.com/arthurhzna/Golang_gRPC/pb/product"
-	"github.com/arthurhzna/Golang_gRPC/pkg/database"
-	"github.com/joho/godotenv"
-	"github.com/xendit/xendit-go"
-	"google.golang.org/grpc"
-	"google.golang.org/grpc/reflection"
-
-	gocache "github.com/patrickmn/go-cache"
-)
-
-func main() {
-	ctx := context.Background()
-	err := godotenv.Load()
-	xendit.Opt.SecretKey = os.Getenv("XENDIT_SECRET_KEY")
-	if err != nil {
-		log.Fatalf("Error loading .env file: %v", err)
-	}
-
-	lis, err := net.Listen("tcp", ":50052")
-	if err != nil {
-		log.Fatalf("Failed to listen: %v", err)
-	}
-	defer lis.Close()
-
-	cacheService := gocache.New(time.Hour*24, time.Hour)
-	authMiddleware := grpcmiddlerware.NewAuthMiddleware(cacheService)
-
-	db := database.ConnectDb(ctx, os.Getenv("DB_URL"))
-	authRepository := repository.NewAuthRepository(db)
-	authService := service.NewAuthService(authRepository, cacheService)
-	authHandler := handler.NewAuthHandler(authService)
-
-	productRepository := repository.NewProductRepository(db)
-	productService := service.NewProductService(productRepository)
-	productHandler := handler.NewProductHandler(productService)
-
-	cartRepository := repository.NewCartRepository(db)
-	cartService := service.NewCartService(productRepository, cartRepository)
-	cartHandler := handler.NewCartHandler(cartService)
-
-	orderRepository := repository.NewOrderRepository(db)
-	orderService := service.NewOrderService(db, orderRepository, productRepository)
-	orderHandler := handler.NewOrderHandler(orderService)
-
-	newsletterRepository := repository.NewNewsletterRepository(db)
-	newsletterService := service.NewNewsletterService(newsletterRepository)
-	newsletterHandler := handler.NewNewsletterHandler(newsletterService)
-
-	grpcServer := grpc.NewServer(grpc.ChainUnaryInterceptor(
-		grpcmiddlerware.ErrorMiddleware,
-		authMiddleware.Middleware,
-	))
-
-	if os.Getenv("ENVIRONMENT") == "DEV" {
-		reflection.Register(grpcServer)
-	}
-
-	auth.RegisterAuthServiceServer(grpcServer, authHandler)
-	product.RegisterProductServiceServer(grpcServer, productHandler)
-	cart.RegisterCartServiceServer(grpcServer, cartHandler)
-	order.RegisterOrderServiceServer(grpcServer, orderHandler)
-	newsletter.RegisterNewsletterServiceServer(grpcServer, newsletterHandler)
-	grpcServer.Serve(lis)
-
-}
+package main
+
+import (
+	"context"
+	"log"
+	"log/slog"
+	"net"
+	"os"
+	"time"
+
+	jwtentity "github.com/arthurhzna/Golang_gRPC/internal/entity/jwt"
+	"github.com/arthurhzna/Golang_gRPC/internal/grpcmiddlerware"
+	"github.com/arthurhzna/Golang_gRPC/internal/handler"
+	"github.com/arthurhzna/Golang_gRPC/internal/logging"
+	"github.com/arthurhzna/Golang_gRPC/internal/mailer"
+	"github.com/arthurhzna/Golang_gRPC/internal/oidc"
+	"github.com/arthurhzna/Golang_gRPC/internal/payment"
+	"github.com/arthurhzna/Golang_gRPC/internal/pubsub"
+	"github.com/arthurhzna/Golang_gRPC/internal/repository"
+	"github.com/arthurhzna/Golang_gRPC/internal/revocation"
+	"github.com/arthurhzna/Golang_gRPC/internal/service"
+	"github.com/arthurhzna/Golang_gRPC/internal/utils"
+	"github.com/arthurhzna/Golang_gRPC/internal/worker"
+	"github.com/arthurhzna/Golang_gRPC/pb/auth"
+	"github.com/arthurhzna/Golang_gRPC/pb/cart"
+	"github.com/arthurhzna/Golang_gRPC/pb/newsletter"
+	"github.com/arthurhzna/Golang_gRPC/pb/order"
+	"github.com/arthurhzna/Golang_gRPC/pb/product"
+	"github.com/arthurhzna/Golang_gRPC/pb/serviceaccount"
+	"github.com/arthurhzna/Golang_gRPC/pb/user"
+	"github.com/arthurhzna/Golang_gRPC/pkg/database"
+	"github.com/joho/godotenv"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/reflection"
+)
+
+func main() {
+	ctx := context.Background()
+	err := godotenv.Load()
+	if err != nil {
+		log.Fatalf("Error loading .env file: %v", err)
+	}
+
+	logger, err := logging.NewLoggerFromEnv()
+	if err != nil {
+		log.Fatalf("Failed to create logger: %v", err)
+	}
+	slog.SetDefault(logger)
+
+	lis, err := net.Listen("tcp", ":50052")
+	if err != nil {
+		log.Fatalf("Failed to listen: %v", err)
+	}
+	defer lis.Close()
+
+	keySet, err := jwtentity.LoadKeySetFromEnv()
+	if err != nil {
+		log.Fatalf("Failed to load jwt keys: %v", err)
+	}
+	if !keySet.CanSign() {
+		log.Fatalf("JWT_SIGNING_KEY_ID is required by the gRPC server")
+	}
+
+	mailService, err := mailer.NewMailerFromEnv()
+	if err != nil {
+		log.Fatalf("Failed to create mailer: %v", err)
+	}
+
+	emailVerificationPolicy, err := service.NewEmailVerificationPolicyFromEnv()
+	if err != nil {
+		log.Fatalf("Failed to read email verification policy: %v", err)
+	}
+	totpPolicy := service.NewTotpPolicyFromEnv()
+
+	trustedProxies, err := utils.NewTrustedProxiesFromEnv()
+	if err != nil {
+		log.Fatalf("Failed to read trusted proxies: %v", err)
+	}
+	clientIpMiddleware := grpcmiddlerware.NewClientIpMiddleware(trustedProxies)
+
+	oidcProviders, err := oidc.NewProvidersFromEnv()
+	if err != nil {
+		log.Fatalf("Failed to create oidc providers: %v", err)
+	}
+
+	db := database.ConnectDb(ctx, os.Getenv("DB_URL"))
+	tokenRevocationStore := revocation.NewPostgresTokenRevocationStore(db)
+	worker.NewTokenRevocationPruneWorker(tokenRevocationStore, time.Hour).Start(ctx)
+
+	permissionRepository := repository.NewPermissionRepository(db)
+	permissionService := service.NewPermissionService(permissionRepository)
+	refreshTokenRepository := repository.NewRefreshTokenRepository(db)
+	userRepository := repository.NewUserRepository(db)
+	userSessionRepository := repository.NewUserSessionRepository(db)
+	userService := service.NewUserService(db, userRepository, refreshTokenRepository, userSessionRepository)
+	userHandler := handler.NewUserHandler(userService)
+	serviceAccountRepository := repository.NewServiceAccountRepository(db)
+	apiKeyRepository := repository.NewApiKeyRepository(db)
+	serviceAccountService := service.NewServiceAccountService(serviceAccountRepository, apiKeyRepository, userRepository, grpcmiddlerware.IsApiKeyScope)
+	serviceAccountHandler := handler.NewServiceAccountHandler(serviceAccountService)
+	authMiddleware := grpcmiddlerware.NewAuthMiddleware(keySet, tokenRevocationStore, permissionService, userService, totpPolicy, serviceAccountService)
+
+	authRepository := repository.NewAuthRepository(db)
+	oneTimeTokenRepository := repository.NewOneTimeTokenRepository(db)
+	loginAttemptRepository := repository.NewLoginAttemptRepository(db)
+	worker.NewLoginAttemptPruneWorker(loginAttemptRepository, service.LoginAttemptRetention, time.Hour).Start(ctx)
+	totpRecoveryCodeRepository := repository.NewTotpRecoveryCodeRepository(db)
+	loginChallengeRepository := repository.NewLoginChallengeRepository(db)
+	userIdentityRepository := repository.NewUserIdentityRepository(db)
+	oidcLoginStateRepository := repository.NewOidcLoginStateRepository(db)
+	authService := service.NewAuthService(db, authRepository, refreshTokenRepository, oneTimeTokenRepository, loginAttemptRepository, totpRecoveryCodeRepository, loginChallengeRepository, userIdentityRepository, oidcLoginStateRepository, userSessionRepository, tokenRevocationStore, keySet, mailService, emailVerificationPolicy, totpPolicy, oidcProviders)
+	authHandler := handler.NewAuthHandler(authService)
+
+	productRepository := repository.NewProductRepository(db)
+	productService := service.NewProductService(productRepository)
+	productHandler := handler.NewProductHandler(productService)
+
+	cartRepository := repository.NewCartRepository(db)
+	cartService := service.NewCartService(productRepository, cartRepository)
+	cartHandler := handler.NewCartHandler(cartService)
+
+	paymentGateway, err := payment.NewPaymentGatewayFromEnv()
+	if err != nil {
+		log.Fatalf("Failed to create payment gateway: %v", err)
+	}
+
+	orderEventBus, err := pubsub.NewOrderEventBusFromEnv(ctx, db)
+	if err != nil {
+		log.Fatalf("Failed to create order event bus: %v", err)
+	}
+
+	orderRepository := repository.NewOrderRepository(db)
+	orderRefundRepository := repository.NewOrderRefundRepository(db)
+	orderService := service.NewOrderService(db, orderRepository, productRepository, orderRefundRepository, cartRepository, paymentGateway, orderEventBus, emailVerificationPolicy)
+	orderHandler := handler.NewOrderHandler(orderService)
+
+	orderExpiryService := service.NewOrderExpiryService(db, orderRepository, productRepository, paymentGateway, orderEventBus)
+	worker.NewOrderExpiryWorker(orderExpiryService, time.Minute).Start(ctx)
+
+	newsletterRepository := repository.NewNewsletterRepository(db)
+	newsletterService := service.NewNewsletterService(newsletterRepository)
+	newsletterHandler := handler.NewNewsletterHandler(newsletterService)
+
+	grpcServer := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(
+			clientIpMiddleware.Middleware,
+			grpcmiddlerware.LoggingMiddleware,
+			grpcmiddlerware.ErrorMiddleware,
+			authMiddleware.Middleware,
+			grpcmiddlerware.ValidationMiddleware,
+		),
+		grpc.ChainStreamInterceptor(
+			clientIpMiddleware.StreamMiddleware,
+			grpcmiddlerware.LoggingStreamMiddleware,
+			grpcmiddlerware.ErrorStreamMiddleware,
+			authMiddleware.StreamMiddleware,
+			grpcmiddlerware.ValidationStreamMiddleware,
+		),
+	)
+
+	if os.Getenv("ENVIRONMENT") == "DEV" {
+		reflection.Register(grpcServer)
+	}
+
+	auth.RegisterAuthServiceServer(grpcServer, authHandler)
+	product.RegisterProductServiceServer(grpcServer, productHandler)
+	cart.RegisterCartServiceServer(grpcServer, cartHandler)
+	order.RegisterOrderServiceServer(grpcServer, orderHandler)
+	newsletter.RegisterNewsletterServiceServer(grpcServer, newsletterHandler)
+	user.RegisterUserServiceServer(grpcServer, userHandler)
+	serviceaccount.RegisterServiceAccountServiceServer(grpcServer, serviceAccountHandler)
+
+	// a method without a policy is denied by both interceptor chains, so refuse to start instead
+	err = grpcmiddlerware.CheckMethodPermissions(grpcServer)
+	if err != nil {
+		log.Fatalf("Invalid method permissions: %v", err)
+	}
+
+	grpcServer.Serve(lis)
+
+}
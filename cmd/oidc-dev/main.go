@@ -0,0 +1,41 @@
+package main
+
+import (
+	"log"
+	"log/slog"
+	"os"
+
+	"github.com/arthurhzna/Golang_gRPC/internal/logging"
+	"github.com/arthurhzna/Golang_gRPC/internal/oidc"
+	"github.com/gofiber/fiber/v2"
+)
+
+// A stand-in OpenID Connect issuer for trying the OIDC login locally, see README.
+func main() {
+
+	logger, err := logging.NewLoggerFromEnv()
+	if err != nil {
+		log.Fatalf("Failed to create logger: %v", err)
+	}
+	slog.SetDefault(logger)
+
+	issuerUrl := os.Getenv("OIDC_DEV_ISSUER_URL")
+	if issuerUrl == "" {
+		issuerUrl = "http://localhost:9000"
+	}
+	listenAddress := os.Getenv("OIDC_DEV_LISTEN_ADDRESS")
+	if listenAddress == "" {
+		listenAddress = ":9000"
+	}
+
+	devIssuer, err := oidc.NewDevIssuer(issuerUrl)
+	if err != nil {
+		log.Fatalf("Failed to create dev issuer: %v", err)
+	}
+
+	app := fiber.New()
+	devIssuer.Register(app)
+
+	slog.Info("Dev OIDC issuer listening", "issuer_url", issuerUrl, "address", listenAddress)
+	log.Fatal(app.Listen(listenAddress))
+}
@@ -1,66 +1,98 @@
-package main
-
-import (
-	"context"
-	"log"
-	"mime"
-	"net/http"
-	"os"
-	"path"
-
-	"github.com/arthurhzna/Golang_gRPC/internal/handler"
-	"github.com/arthurhzna/Golang_gRPC/internal/repository"
-	"github.com/arthurhzna/Golang_gRPC/internal/service"
-	"github.com/arthurhzna/Golang_gRPC/pkg/database"
-	"github.com/gofiber/fiber/v2"
-	"github.com/gofiber/fiber/v2/middleware/cors"
-	"github.com/joho/godotenv"
-)
-
-func handleGetFileName(c *fiber.Ctx) error {
-	fileNameParam := c.Params("filename")
-	filePath := path.Join("storage", "product", fileNameParam)
-	if _, err := os.Stat(filePath); err != nil {
-		if os.IsNotExist(err) {
-			return c.Status(http.StatusNotFound).SendString("Not Found")
-		}
-		return c.Status(http.StatusInternalServerError).SendString("Internal Server Error")
-	}
-
-	file, err := os.Open(filePath)
-	if err != nil {
-		return c.Status(http.StatusInternalServerError).SendString("Internal Server Error")
-	}
-
-	ext := path.Ext(filePath)
-	mineType := mime.TypeByExtension(ext)
-
-	c.Set("Content-Type", mineType)
-
-	return c.SendStream(file)
-
-}
-
-func main() {
-
-	err := godotenv.Load()
-	if err != nil {
-		log.Fatalf("Error loading .env file: %v", err)
-	}
-
-	ctx := context.Background()
-	app := fiber.New()
-	app.Use(cors.New())
-
-	db := database.ConnectDb(ctx, os.Getenv("DB_URL"))
-
-	orderRepository := repository.NewOrderRepository(db)
-	webhookService := service.NewWebhookService(orderRepository)
-	webhookHandler := handler.NewWebhookHandler(webhookService)
-
-	app.Get("/storage/product/:filename", handleGetFileName)
-	app.Post("/product/upload", handler.UploadHandler)
-	app.Post("webhook/xendit/invoice", webhookHandler.ReceiveInvoice)
-
-	app.Listen(":3000")
-}
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"log/slog"
+	"mime"
+	"net/http"
+	"os"
+	"path"
+
+	jwtentity "github.com/arthurhzna/Golang_gRPC/internal/entity/jwt"
+	"github.com/arthurhzna/Golang_gRPC/internal/handler"
+	"github.com/arthurhzna/Golang_gRPC/internal/logging"
+	"github.com/arthurhzna/Golang_gRPC/internal/payment"
+	"github.com/arthurhzna/Golang_gRPC/internal/pubsub"
+	"github.com/arthurhzna/Golang_gRPC/internal/repository"
+	"github.com/arthurhzna/Golang_gRPC/internal/service"
+	"github.com/arthurhzna/Golang_gRPC/pkg/database"
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/cors"
+	"github.com/joho/godotenv"
+)
+
+func handleGetFileName(c *fiber.Ctx) error {
+	fileNameParam := c.Params("filename")
+	filePath := path.Join("storage", "product", fileNameParam)
+	if _, err := os.Stat(filePath); err != nil {
+		if os.IsNotExist(err) {
+			return c.Status(http.StatusNotFound).SendString("Not Found")
+		}
+		return c.Status(http.StatusInternalServerError).SendString("Internal Server Error")
+	}
+
+	file, err := os.Open(filePath)
+	if err != nil {
+		return c.Status(http.StatusInternalServerError).SendString("Internal Server Error")
+	}
+
+	ext := path.Ext(filePath)
+	mineType := mime.TypeByExtension(ext)
+
+	c.Set("Content-Type", mineType)
+
+	return c.SendStream(file)
+
+}
+
+func main() {
+
+	err := godotenv.Load()
+	if err != nil {
+		log.Fatalf("Error loading .env file: %v", err)
+	}
+
+	logger, err := logging.NewLoggerFromEnv()
+	if err != nil {
+		log.Fatalf("Failed to create logger: %v", err)
+	}
+	slog.SetDefault(logger)
+
+	ctx := context.Background()
+	app := fiber.New()
+	app.Use(logging.FiberMiddleware)
+	app.Use(cors.New())
+
+	keySet, err := jwtentity.LoadKeySetFromEnv()
+	if err != nil {
+		log.Fatalf("Failed to load jwt keys: %v", err)
+	}
+	jwksHandler := handler.NewJwksHandler(keySet)
+
+	db := database.ConnectDb(ctx, os.Getenv("DB_URL"))
+
+	paymentGateway, err := payment.NewPaymentGatewayFromEnv()
+	if err != nil {
+		log.Fatalf("Failed to create payment gateway: %v", err)
+	}
+
+	orderEventBus, err := pubsub.NewOrderEventBusFromEnv(ctx, db)
+	if err != nil {
+		log.Fatalf("Failed to create order event bus: %v", err)
+	}
+
+	orderRepository := repository.NewOrderRepository(db)
+	productRepository := repository.NewProductRepository(db)
+	webhookEventRepository := repository.NewWebhookEventRepository(db)
+	webhookService := service.NewWebhookService(db, orderRepository, productRepository, webhookEventRepository, paymentGateway, orderEventBus)
+	webhookHandler := handler.NewWebhookHandler(webhookService)
+
+	app.Get("/.well-known/jwks.json", jwksHandler.GetJwks)
+	app.Get("/storage/product/:filename", handleGetFileName)
+	app.Post("/product/upload", handler.UploadHandler)
+	app.Post(fmt.Sprintf("webhook/%s/invoice", paymentGateway.Provider()), webhookHandler.ReceiveInvoice)
+
+	app.Listen(":3000")
+}
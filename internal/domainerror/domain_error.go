@@ -0,0 +1,130 @@
+// Package domainerror holds the errors services return when a request cannot be served as asked.
+// The error middleware turns them into the matching gRPC status with google.rpc.ErrorInfo and BadRequest details.
+package domainerror
+
+import (
+	"errors"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+)
+
+// Domain is the ErrorInfo domain of every error of this service
+const Domain = "golang-grpc"
+
+type Kind string
+
+const (
+	KindNotFound           Kind = "not_found"
+	KindInvalidArgument    Kind = "invalid_argument"
+	KindPermissionDenied   Kind = "permission_denied"
+	KindFailedPrecondition Kind = "failed_precondition"
+	KindConflict           Kind = "conflict"
+)
+
+var kindCodes = map[Kind]codes.Code{
+	KindNotFound:           codes.NotFound,
+	KindInvalidArgument:    codes.InvalidArgument,
+	KindPermissionDenied:   codes.PermissionDenied,
+	KindFailedPrecondition: codes.FailedPrecondition,
+	KindConflict:           codes.AlreadyExists,
+}
+
+type FieldViolation struct {
+	Field       string
+	Description string
+	// Reason is the id of the broken validation rule, when there is one
+	Reason string
+}
+
+// Error is a domain error. Reason is a stable UPPER_SNAKE_CASE identifier clients can switch on,
+// Message is shown to users as it was in BaseResponse.
+type Error struct {
+	Kind            Kind
+	Reason          string
+	Message         string
+	Metadata        map[string]string
+	FieldViolations []FieldViolation
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// WithField names the request field the message is about, it is sent as a BadRequest field violation.
+func (e *Error) WithField(field string) *Error {
+	return e.WithFieldViolation(field, e.Message)
+}
+
+// WithFieldViolation adds a violation of a request field, named by its proto path such as products[0].quantity.
+func (e *Error) WithFieldViolation(field string, description string) *Error {
+	e.FieldViolations = append(e.FieldViolations, FieldViolation{Field: field, Description: description})
+	return e
+}
+
+func (e *Error) WithMetadata(key string, value string) *Error {
+	if e.Metadata == nil {
+		e.Metadata = make(map[string]string)
+	}
+	e.Metadata[key] = value
+	return e
+}
+
+// GRPCStatus lets status.FromError and status.Code read the status of a domain error.
+func (e *Error) GRPCStatus() *status.Status {
+	details := []protoadapt.MessageV1{&errdetails.ErrorInfo{
+		Reason:   e.Reason,
+		Domain:   Domain,
+		Metadata: e.Metadata,
+	}}
+	if len(e.FieldViolations) > 0 {
+		badRequest := &errdetails.BadRequest{}
+		for _, fieldViolation := range e.FieldViolations {
+			badRequest.FieldViolations = append(badRequest.FieldViolations, &errdetails.BadRequest_FieldViolation{
+				Field:       fieldViolation.Field,
+				Description: fieldViolation.Description,
+				Reason:      fieldViolation.Reason,
+			})
+		}
+		details = append(details, badRequest)
+	}
+
+	st := status.New(kindCodes[e.Kind], e.Message)
+	stWithDetails, err := st.WithDetails(details...)
+	if err != nil {
+		return st
+	}
+	return stWithDetails
+}
+
+// As returns the domain error in the chain of err.
+func As(err error) (*Error, bool) {
+	var domainErr *Error
+	if errors.As(err, &domainErr) {
+		return domainErr, true
+	}
+	return nil, false
+}
+
+func NotFound(reason string, message string) *Error {
+	return &Error{Kind: KindNotFound, Reason: reason, Message: message}
+}
+
+func InvalidArgument(reason string, message string) *Error {
+	return &Error{Kind: KindInvalidArgument, Reason: reason, Message: message}
+}
+
+func PermissionDenied(reason string, message string) *Error {
+	return &Error{Kind: KindPermissionDenied, Reason: reason, Message: message}
+}
+
+func FailedPrecondition(reason string, message string) *Error {
+	return &Error{Kind: KindFailedPrecondition, Reason: reason, Message: message}
+}
+
+// Conflict is answered with codes.AlreadyExists, the request collides with data that already exists.
+func Conflict(reason string, message string) *Error {
+	return &Error{Kind: KindConflict, Reason: reason, Message: message}
+}
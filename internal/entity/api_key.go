@@ -0,0 +1,18 @@
+package entity
+
+import "time"
+
+type ApiKey struct {
+	Id               string
+	ServiceAccountId string
+	Name             string
+	Prefix           string
+	KeyHash          string
+	// Scopes are the full gRPC method names the key may call
+	Scopes     []string
+	CreatedAt  time.Time
+	CreatedBy  string
+	ExpiresAt  *time.Time
+	LastUsedAt *time.Time
+	RevokedAt  *time.Time
+}
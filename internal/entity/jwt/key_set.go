@@ -0,0 +1,214 @@
+package jwt
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/arthurhzna/Golang_gRPC/internal/utils"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// jwtKey is one key of the key set, privateKey is nil when only the public key is known.
+type jwtKey struct {
+	kid        string
+	method     jwt.SigningMethod
+	privateKey crypto.Signer
+	publicKey  crypto.PublicKey
+}
+
+// KeySet holds every key tokens may be verified with and the one new tokens are signed with.
+// Rotating keys is done by adding the new key, switching JWT_SIGNING_KEY_ID to it, and removing the old key
+// once the access tokens signed with it have expired.
+type KeySet struct {
+	signingKey *jwtKey
+	keys       map[string]*jwtKey
+}
+
+// LoadKeySetFromEnv reads every PEM key of JWT_KEYS_DIR, the file name without extension is the kid.
+// Private keys (PKCS#8 RSA or Ed25519, or PKCS#1 RSA) can sign and verify, public keys (PKIX) only verify.
+// JWT_SIGNING_KEY_ID selects the signing key, servers that only verify tokens can leave it empty.
+func LoadKeySetFromEnv() (*KeySet, error) {
+	keysDir := os.Getenv("JWT_KEYS_DIR")
+	if keysDir == "" {
+		return nil, fmt.Errorf("JWT_KEYS_DIR is not set")
+	}
+
+	paths, err := filepath.Glob(filepath.Join(keysDir, "*.pem"))
+	if err != nil {
+		return nil, err
+	}
+
+	keySet := &KeySet{
+		keys: make(map[string]*jwtKey),
+	}
+	for _, path := range paths {
+		key, err := loadKey(path)
+		if err != nil {
+			return nil, fmt.Errorf("load jwt key %s: %w", path, err)
+		}
+		keySet.keys[key.kid] = key
+	}
+	if len(keySet.keys) == 0 {
+		return nil, fmt.Errorf("no jwt keys found in %s", keysDir)
+	}
+
+	signingKeyId := os.Getenv("JWT_SIGNING_KEY_ID")
+	if signingKeyId != "" {
+		signingKey, ok := keySet.keys[signingKeyId]
+		if !ok {
+			return nil, fmt.Errorf("jwt signing key %q not found in %s", signingKeyId, keysDir)
+		}
+		if signingKey.privateKey == nil {
+			return nil, fmt.Errorf("jwt signing key %q is a public key", signingKeyId)
+		}
+		keySet.signingKey = signingKey
+	}
+
+	return keySet, nil
+}
+
+func loadKey(path string) (*jwtKey, error) {
+	pemBytes, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	block, _ := pem.Decode(pemBytes)
+	if block == nil {
+		return nil, fmt.Errorf("no PEM block found")
+	}
+
+	key := &jwtKey{
+		kid: strings.TrimSuffix(filepath.Base(path), filepath.Ext(path)),
+	}
+
+	var parsedKey any
+	switch block.Type {
+	case "PRIVATE KEY":
+		parsedKey, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	case "RSA PRIVATE KEY":
+		parsedKey, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "PUBLIC KEY":
+		parsedKey, err = x509.ParsePKIXPublicKey(block.Bytes)
+	default:
+		return nil, fmt.Errorf("unsupported PEM block %q", block.Type)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	switch k := parsedKey.(type) {
+	case *rsa.PrivateKey:
+		key.method = jwt.SigningMethodRS256
+		key.privateKey = k
+		key.publicKey = &k.PublicKey
+	case *rsa.PublicKey:
+		key.method = jwt.SigningMethodRS256
+		key.publicKey = k
+	case ed25519.PrivateKey:
+		key.method = jwt.SigningMethodEdDSA
+		key.privateKey = k
+		key.publicKey = k.Public()
+	case ed25519.PublicKey:
+		key.method = jwt.SigningMethodEdDSA
+		key.publicKey = k
+	default:
+		return nil, fmt.Errorf("unsupported key type %T, use RSA or Ed25519", parsedKey)
+	}
+	return key, nil
+}
+
+func (ks *KeySet) CanSign() bool {
+	return ks.signingKey != nil
+}
+
+// SignClaims signs the claims with the signing key and puts its kid in the token header.
+func (ks *KeySet) SignClaims(claims *JwtClaims) (string, error) {
+	if ks.signingKey == nil {
+		return "", fmt.Errorf("jwt key set has no signing key")
+	}
+	token := jwt.NewWithClaims(ks.signingKey.method, claims)
+	token.Header["kid"] = ks.signingKey.kid
+	return token.SignedString(ks.signingKey.privateKey)
+}
+
+// GetClaimsFromToken verifies the token with the key named by its kid header.
+func (ks *KeySet) GetClaimsFromToken(jwtToken string) (*JwtClaims, error) {
+	token, err := jwt.ParseWithClaims(jwtToken, &JwtClaims{}, func(token *jwt.Token) (interface{}, error) {
+		kid, _ := token.Header["kid"].(string)
+		key, ok := ks.keys[kid]
+		if !ok {
+			return nil, fmt.Errorf("unknown key id: %q", kid)
+		}
+		if token.Method.Alg() != key.method.Alg() {
+			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+		}
+		return key.publicKey, nil
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodRS256.Alg(), jwt.SigningMethodEdDSA.Alg()}))
+	if err != nil {
+		return nil, utils.UnaunthorizedResponse()
+	}
+
+	if !token.Valid {
+		return nil, utils.UnaunthorizedResponse()
+	}
+
+	if claims, ok := token.Claims.(*JwtClaims); ok {
+		return claims, nil
+	}
+
+	return nil, utils.UnaunthorizedResponse()
+}
+
+type Jwk struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	N   string `json:"n,omitempty"`
+	E   string `json:"e,omitempty"`
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+}
+
+type Jwks struct {
+	Keys []Jwk `json:"keys"`
+}
+
+// Jwks returns the public part of every key, sorted by kid, as a JSON Web Key Set (RFC 7517).
+func (ks *KeySet) Jwks() *Jwks {
+	jwks := &Jwks{
+		Keys: make([]Jwk, 0, len(ks.keys)),
+	}
+	for _, key := range ks.keys {
+		jwk := Jwk{
+			Kid: key.kid,
+			Use: "sig",
+			Alg: key.method.Alg(),
+		}
+		switch publicKey := key.publicKey.(type) {
+		case *rsa.PublicKey:
+			jwk.Kty = "RSA"
+			jwk.N = base64.RawURLEncoding.EncodeToString(publicKey.N.Bytes())
+			jwk.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(publicKey.E)).Bytes())
+		case ed25519.PublicKey:
+			jwk.Kty = "OKP"
+			jwk.Crv = "Ed25519"
+			jwk.X = base64.RawURLEncoding.EncodeToString(publicKey)
+		}
+		jwks.Keys = append(jwks.Keys, jwk)
+	}
+	sort.Slice(jwks.Keys, func(i, j int) bool {
+		return jwks.Keys[i].Kid < jwks.Keys[j].Kid
+	})
+	return jwks
+}
@@ -0,0 +1,11 @@
+package entity
+
+import "time"
+
+// LoginAttempt counts the recent failed logins of one key, an email or a client IP.
+type LoginAttempt struct {
+	Key          string
+	FailedCount  int
+	LastFailedAt time.Time
+	LockedUntil  *time.Time
+}
@@ -0,0 +1,15 @@
+package entity
+
+import "time"
+
+// LoginChallenge is handed out by Login after the password of a two-factor account was checked,
+// it is exchanged for tokens together with a TOTP or recovery code.
+type LoginChallenge struct {
+	Id          string
+	UserId      string
+	TokenHash   string
+	ExpiresAt   time.Time
+	CreatedAt   time.Time
+	UsedAt      *time.Time
+	FailedCount int
+}
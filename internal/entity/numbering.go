@@ -1,6 +1,6 @@
-package entity
-
-type Numbering struct {
-	Module string
-	Number int64
-}
+package entity
+
+type Numbering struct {
+	Module string
+	Number int64
+}
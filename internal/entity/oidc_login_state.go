@@ -0,0 +1,16 @@
+package entity
+
+import "time"
+
+// OidcLoginState is kept between StartOidcLogin and OidcLogin, the state travels through the provider
+// while the nonce and the PKCE code verifier never leave the server.
+type OidcLoginState struct {
+	Id           string
+	StateHash    string
+	Provider     string
+	Nonce        string
+	CodeVerifier string
+	ExpiresAt    time.Time
+	CreatedAt    time.Time
+	UsedAt       *time.Time
+}
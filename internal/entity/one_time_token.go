@@ -0,0 +1,23 @@
+package entity
+
+import "time"
+
+// Purposes of a one time token, a token is only accepted for the purpose it was issued for.
+const (
+	OneTimeTokenPurposePasswordReset     = "password_reset"
+	OneTimeTokenPurposeEmailVerification = "email_verification"
+	OneTimeTokenPurposeEmailChange       = "email_change"
+)
+
+// OneTimeToken is a single use token mailed to the user as a link, only its hash is stored.
+type OneTimeToken struct {
+	Id        string
+	UserId    string
+	Purpose   string
+	TokenHash string
+	// NewEmail is the address an email change token confirms, it is only set for that purpose
+	NewEmail  *string
+	ExpiresAt time.Time
+	CreatedAt time.Time
+	UsedAt    *time.Time
+}
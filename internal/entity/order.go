@@ -1,56 +1,62 @@
-package entity
-
-import "time"
-
-const (
-	OrderStatusCodeUnpaid   = "unpaid"
-	OrderStatusCodePaid     = "paid"
-	OrderStatusCodeShipped  = "shipped"
-	OrderStatusCodeDone     = "done"
-	OrderStatusCodeExpired  = "expired"
-	OrderStatusCodeCanceled = "canceled"
-)
-
-type Order struct {
-	Id                   string
-	Number               string
-	UserId               string
-	OrderStatusCode      string
-	UserFullName         string
-	Address              string
-	PhoneNumber          string
-	Notes                *string
-	Total                float64
-	ExpiredAt            *time.Time
-	CreatedAt            time.Time
-	CreatedBy            string
-	UpdatedAt            *time.Time
-	UpdatedBy            *string
-	DeletedAt            *time.Time
-	DeletedBy            *string
-	IsDeleted            bool
-	XenditInvoiceId      *string
-	XenditInvoiceUrl     *string
-	XenditPaidAt         *time.Time
-	XenditPaymentMethod  *string
-	XenditPaymentChannel *string
-
-	Items []*OrderItem
-}
-
-type OrderItem struct {
-	Id                   string
-	ProductId            string
-	ProductName          string
-	ProductImageFileName string
-	ProductPrice         float64
-	Quantity             int64
-	OrderId              string
-	CreatedAt            time.Time
-	CreatedBy            string
-	UpdatedAt            *time.Time
-	UpdatedBy            *string
-	DeletedAt            *time.Time
-	DeletedBy            *string
-	IsDeleted            bool
-}
+package entity
+
+import "time"
+
+const (
+	OrderStatusCodeUnpaid   = "unpaid"
+	OrderStatusCodePaid     = "paid"
+	OrderStatusCodeShipped  = "shipped"
+	OrderStatusCodeDone     = "done"
+	OrderStatusCodeExpired  = "expired"
+	OrderStatusCodeCanceled = "canceled"
+
+	OrderStatusCodeRefunded          = "refunded"
+	OrderStatusCodePartiallyRefunded = "partially_refunded"
+)
+
+type Order struct {
+	Id                string
+	Number            string
+	UserId            string
+	OrderStatusCode   string
+	UserFullName      string
+	Address           string
+	PhoneNumber       string
+	Notes             *string
+	Total             float64
+	ExpiredAt         *time.Time
+	CreatedAt         time.Time
+	CreatedBy         string
+	UpdatedAt         *time.Time
+	UpdatedBy         *string
+	DeletedAt         *time.Time
+	DeletedBy         *string
+	IsDeleted         bool
+	PaymentProvider   *string
+	PaymentInvoiceId  *string
+	PaymentInvoiceUrl *string
+	PaidAt            *time.Time
+	PaymentMethod     *string
+	PaymentChannel    *string
+	RefundedTotal     float64
+
+	Items []*OrderItem
+}
+
+type OrderItem struct {
+	Id                   string
+	ProductId            string
+	ProductName          string
+	ProductImageFileName string
+	ProductPrice         float64
+	Quantity             int64
+	RefundedQuantity     int64
+	OrderId              string
+	CreatedAt            time.Time
+	CreatedBy            string
+	UpdatedAt            *time.Time
+	UpdatedBy            *string
+	DeletedAt            *time.Time
+	DeletedBy            *string
+	IsDeleted            bool
+}
@@ -0,0 +1,36 @@
+package entity
+
+import "time"
+
+const (
+	// OrderRefundStatusPending is a refund recorded before the payment provider is called,
+	// a retried RefundOrder finishes it with the same idempotency key instead of starting a new refund
+	OrderRefundStatusPending   = "pending"
+	OrderRefundStatusCompleted = "completed"
+	OrderRefundStatusFailed    = "failed"
+)
+
+type OrderRefund struct {
+	Id              string
+	OrderId         string
+	Amount          float64
+	Reason          string
+	Restock         bool
+	Status          string
+	PaymentProvider *string
+	PaymentRefundId *string
+	CreatedAt       time.Time
+	CreatedBy       string
+	UpdatedAt       *time.Time
+
+	Items []*OrderRefundItem
+}
+
+type OrderRefundItem struct {
+	Id            string
+	OrderRefundId string
+	ProductId     string
+	Quantity      int64
+	Amount        float64
+	CreatedAt     time.Time
+}
@@ -0,0 +1,88 @@
+package entity
+
+import "time"
+
+// Who asks for an order status change. The customer actor only applies to the owner of the order.
+const (
+	OrderActorCustomer = "customer"
+	OrderActorAdmin    = "admin"
+	OrderActorSystem   = "system"
+	// OrderActorRefund is RefundOrder, refund statuses are only reached together with a gateway refund and its ledger entry
+	OrderActorRefund = "refund"
+)
+
+type OrderStatusTransition struct {
+	From   string
+	To     string
+	Actors []string
+}
+
+// OrderStatusTransitions is the order state machine, a status change not listed here is not allowed.
+var OrderStatusTransitions = []OrderStatusTransition{
+	// payment is confirmed by the payment webhook, an admin can mark it paid by hand
+	{From: OrderStatusCodeUnpaid, To: OrderStatusCodePaid, Actors: []string{OrderActorAdmin, OrderActorSystem}},
+	{From: OrderStatusCodeUnpaid, To: OrderStatusCodeCanceled, Actors: []string{OrderActorCustomer, OrderActorAdmin}},
+	{From: OrderStatusCodeUnpaid, To: OrderStatusCodeExpired, Actors: []string{OrderActorSystem}},
+	{From: OrderStatusCodePaid, To: OrderStatusCodeShipped, Actors: []string{OrderActorAdmin}},
+	{From: OrderStatusCodeShipped, To: OrderStatusCodeDone, Actors: []string{OrderActorCustomer, OrderActorAdmin}},
+
+	{From: OrderStatusCodePaid, To: OrderStatusCodePartiallyRefunded, Actors: []string{OrderActorRefund}},
+	{From: OrderStatusCodePaid, To: OrderStatusCodeRefunded, Actors: []string{OrderActorRefund}},
+	{From: OrderStatusCodeShipped, To: OrderStatusCodePartiallyRefunded, Actors: []string{OrderActorRefund}},
+	{From: OrderStatusCodeShipped, To: OrderStatusCodeRefunded, Actors: []string{OrderActorRefund}},
+	{From: OrderStatusCodeDone, To: OrderStatusCodePartiallyRefunded, Actors: []string{OrderActorRefund}},
+	{From: OrderStatusCodeDone, To: OrderStatusCodeRefunded, Actors: []string{OrderActorRefund}},
+	{From: OrderStatusCodePartiallyRefunded, To: OrderStatusCodePartiallyRefunded, Actors: []string{OrderActorRefund}},
+	{From: OrderStatusCodePartiallyRefunded, To: OrderStatusCodeRefunded, Actors: []string{OrderActorRefund}},
+}
+
+func CanTransitionOrderStatus(from string, to string, actor string) bool {
+	for _, transition := range OrderStatusTransitions {
+		if transition.From != from || transition.To != to {
+			continue
+		}
+		for _, allowedActor := range transition.Actors {
+			if allowedActor == actor {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// IsFinalOrderStatus reports whether the order is over: no transition leaves the status, except a later refund.
+// A done order can still be refunded, yet nobody waits on it anymore.
+func IsFinalOrderStatus(code string) bool {
+	for _, transition := range OrderStatusTransitions {
+		if transition.From != code {
+			continue
+		}
+		for _, actor := range transition.Actors {
+			if actor != OrderActorRefund {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func IsOrderStatusCode(code string) bool {
+	for _, transition := range OrderStatusTransitions {
+		if transition.From == code || transition.To == code {
+			return true
+		}
+	}
+	return false
+}
+
+type OrderStatusHistory struct {
+	Id             string
+	OrderId        string
+	FromStatusCode *string
+	ToStatusCode   string
+	// Actor is the user id, or "System" for automated changes
+	Actor     string
+	ActorRole string
+	Reason    string
+	CreatedAt time.Time
+}
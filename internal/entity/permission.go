@@ -0,0 +1,34 @@
+package entity
+
+import "context"
+
+// Permissions granted to roles through the role_permission table.
+const (
+	PermissionProductManage = "product.manage"
+	// PermissionOrderReadAll allows reading orders of every user, not only the own ones
+	PermissionOrderReadAll = "order.read_all"
+	// PermissionOrderManage allows the admin order status changes, e.g. marking an order as shipped
+	PermissionOrderManage = "order.manage"
+	PermissionOrderRefund = "order.refund"
+	// PermissionUserManage allows managing other users' accounts, e.g. unlocking a locked login
+	PermissionUserManage = "user.manage"
+	// PermissionServiceAccountManage allows creating service accounts and issuing or revoking their API keys
+	PermissionServiceAccountManage = "service_account.manage"
+)
+
+type permissionContextKey string
+
+var PermissionContextKeyValue permissionContextKey = "permissions"
+
+func SetPermissionsToContext(ctx context.Context, permissions map[string]bool) context.Context {
+	return context.WithValue(ctx, PermissionContextKeyValue, permissions)
+}
+
+// HasPermission reports whether the caller of the request in ctx has been granted the permission.
+func HasPermission(ctx context.Context, permission string) bool {
+	permissions, ok := ctx.Value(PermissionContextKeyValue).(map[string]bool)
+	if !ok {
+		return false
+	}
+	return permissions[permission]
+}
@@ -0,0 +1,17 @@
+package entity
+
+import "time"
+
+// RefreshToken is one link of a rotation chain. All tokens issued from the same login share the FamilyId,
+// which is also the sid claim of their access tokens.
+type RefreshToken struct {
+	Id         string
+	UserId     string
+	FamilyId   string
+	TokenHash  string
+	ExpiresAt  time.Time
+	CreatedAt  time.Time
+	UsedAt     *time.Time
+	ReplacedBy *string
+	RevokedAt  *time.Time
+}
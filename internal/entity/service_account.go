@@ -0,0 +1,12 @@
+package entity
+
+import "time"
+
+// ServiceAccount is a machine client, such as a warehouse or ERP integration, that calls the API with API keys.
+type ServiceAccount struct {
+	Id        string
+	Name      string
+	RoleCode  string
+	CreatedAt time.Time
+	CreatedBy string
+}
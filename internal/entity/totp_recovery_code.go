@@ -0,0 +1,12 @@
+package entity
+
+import "time"
+
+// TotpRecoveryCode is a single use code that replaces the authenticator app when it is lost.
+type TotpRecoveryCode struct {
+	Id        string
+	UserId    string
+	CodeHash  string
+	CreatedAt time.Time
+	UsedAt    *time.Time
+}
@@ -1,36 +1,45 @@
-package entity
-
-import "time"
-
-const (
-	UserRoleAdmin    = "admin"
-	UserRoleCustomer = "custumer"
-)
-
-type UserRole struct {
-	Id        string
-	Name      string
-	Code      string
-	CreatedAt time.Time
-	CreatedBy *string
-	UpdateAt  time.Time
-	UpdatedBy *string
-	DeletedAt *time.Time
-	DeletedBy *string
-	IsDeleted bool
-}
-
-type User struct {
-	Id        string
-	FullName  string
-	Email     string
-	Password  string
-	RoleCode  string
-	CreatedAt time.Time
-	CreatedBy *string
-	UpdatedAt time.Time
-	UpdatedBy *string
-	DeletedAt *time.Time
-	DeletedBy *string
-	IsDeleted bool
-}
+package entity
+
+import "time"
+
+const (
+	UserRoleAdmin    = "admin"
+	UserRoleCustomer = "custumer"
+)
+
+type UserRole struct {
+	Id        string
+	Name      string
+	Code      string
+	CreatedAt time.Time
+	CreatedBy *string
+	UpdateAt  time.Time
+	UpdatedBy *string
+	DeletedAt *time.Time
+	DeletedBy *string
+	IsDeleted bool
+}
+
+type User struct {
+	Id        string
+	FullName  string
+	Email     string
+	Password  string
+	RoleCode  string
+	CreatedAt time.Time
+	CreatedBy *string
+	UpdatedAt time.Time
+	UpdatedBy *string
+	DeletedAt *time.Time
+	DeletedBy *string
+	IsDeleted bool
+	// EmailVerifiedAt is nil until the user opens the link sent at registration
+	EmailVerifiedAt *time.Time
+	PhoneNumber     *string
+	Address         *string
+	// TotpSecret is set by EnrollTotp, two-factor authentication is only enforced once TotpEnabledAt is set
+	TotpSecret    *string
+	TotpEnabledAt *time.Time
+	// TotpLastUsedStep is the time step of the last accepted code, so a code cannot be replayed
+	TotpLastUsedStep *int64
+}
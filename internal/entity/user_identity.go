@@ -0,0 +1,13 @@
+package entity
+
+import "time"
+
+// UserIdentity links a user to the subject of an external OpenID Connect provider.
+type UserIdentity struct {
+	Id        string
+	UserId    string
+	Provider  string
+	Subject   string
+	Email     string
+	CreatedAt time.Time
+}
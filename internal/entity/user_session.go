@@ -0,0 +1,16 @@
+package entity
+
+import "time"
+
+// UserSession is one login of a user. Its Id is the family id of the refresh tokens and the sid claim of the access tokens,
+// ExpiresAt follows the latest refresh token of the family.
+type UserSession struct {
+	Id         string
+	UserId     string
+	UserAgent  string
+	IpAddress  string
+	CreatedAt  time.Time
+	LastSeenAt time.Time
+	ExpiresAt  time.Time
+	RevokedAt  *time.Time
+}
@@ -0,0 +1,23 @@
+package entity
+
+import "time"
+
+const (
+	WebhookEventResultReceived  = "received"
+	WebhookEventResultProcessed = "processed"
+	WebhookEventResultIgnored   = "ignored"
+	WebhookEventResultRejected  = "rejected"
+)
+
+type PaymentWebhookEvent struct {
+	Id        string
+	Provider  string
+	InvoiceId string
+	OrderId   string
+	Status    string
+	Amount    float64
+	Payload   string
+	Result    string
+	CreatedAt time.Time
+	UpdatedAt *time.Time
+}
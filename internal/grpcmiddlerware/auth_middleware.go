@@ -1,65 +1,191 @@
-// middleware in here because have jwt entity in internal/entity/jwt
-package grpcmiddlerware
-
-import (
-	"context"
-
-	"google.golang.org/grpc"
-
-	jwtentity "github.com/arthurhzna/Golang_gRPC/internal/entity/jwt"
-	"github.com/arthurhzna/Golang_gRPC/internal/utils"
-	"github.com/patrickmn/go-cache"
-)
-
-type authMiddleware struct {
-	cacheService *cache.Cache
-}
-
-func NewAuthMiddleware(cacheService *cache.Cache) *authMiddleware {
-	return &authMiddleware{
-		cacheService: cacheService,
-	}
-}
-
-var publicApis = map[string]bool{
-	"/auth.AuthService/Login":                           true,
-	"/auth.AuthService/Register":                        true,
-	"/product.ProductService/DetailProduct":             true,
-	"/product.ProductService/ListProduct":               true,
-	"/newsletter.NewsletterService/SubscribeNewsletter": true,
-}
-
-func (am *authMiddleware) Middleware(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp any, err error) {
-
-	if publicApis[info.FullMethod] { // this path get from info.FullMethod
-		return handler(ctx, req)
-	} // allow login and register without authentication jwt
-
-	// log.Printf("AuthMiddleware: Method=%s", info.FullMethod)
-
-	jwtToken, err := jwtentity.ParseTokenFromContext(ctx)
-	if err != nil {
-		return nil, err
-	}
-
-	_, ok := am.cacheService.Get(jwtToken)
-	if ok {
-		return nil, utils.UnaunthorizedResponse()
-	}
-
-	claims, err := jwtentity.GetClaimsFromToken(jwtToken)
-	if err != nil {
-		// log.Printf("AuthMiddleware: error=%v", err)
-		return nil, err
-	}
-
-	// log.Printf("AuthMiddleware: claims=%+v", claims)
-
-	ctx = claims.SetToContext(ctx) // store the claims in the context
-
-	res, err := handler(ctx, req)
-
-	// log.Printf("AuthMiddleware: res=%+v", res)
-
-	return res, nil
-}
+// middleware in here because have jwt entity in internal/entity/jwt
+package grpcmiddlerware
+
+import (
+	"context"
+	"slices"
+
+	"github.com/golang-jwt/jwt/v5"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/arthurhzna/Golang_gRPC/internal/entity"
+	jwtentity "github.com/arthurhzna/Golang_gRPC/internal/entity/jwt"
+	"github.com/arthurhzna/Golang_gRPC/internal/logging"
+	"github.com/arthurhzna/Golang_gRPC/internal/revocation"
+	"github.com/arthurhzna/Golang_gRPC/internal/service"
+	"github.com/arthurhzna/Golang_gRPC/internal/utils"
+)
+
+type authMiddleware struct {
+	keySet                *jwtentity.KeySet
+	tokenRevocationStore  revocation.TokenRevocationStore
+	permissionService     service.IPermissionService
+	userService           service.IUserService
+	totpPolicy            service.TotpPolicy
+	serviceAccountService service.IServiceAccountService
+}
+
+func NewAuthMiddleware(keySet *jwtentity.KeySet, tokenRevocationStore revocation.TokenRevocationStore, permissionService service.IPermissionService, userService service.IUserService, totpPolicy service.TotpPolicy, serviceAccountService service.IServiceAccountService) *authMiddleware {
+	return &authMiddleware{
+		keySet:                keySet,
+		tokenRevocationStore:  tokenRevocationStore,
+		permissionService:     permissionService,
+		userService:           userService,
+		totpPolicy:            totpPolicy,
+		serviceAccountService: serviceAccountService,
+	}
+}
+
+func (am *authMiddleware) Middleware(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp any, err error) {
+
+	ctx, err = am.authorize(ctx, info.FullMethod)
+	if err != nil {
+		return nil, err
+	}
+
+	return handler(ctx, req)
+}
+
+// contextServerStream replaces the stream context, so handlers can read the claims and the request id from stream.Context()
+type contextServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (cs *contextServerStream) Context() context.Context {
+	return cs.ctx
+}
+
+// StreamMiddleware applies the same method policy as Middleware to streaming RPCs, it runs once when the stream opens.
+func (am *authMiddleware) StreamMiddleware(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+
+	ctx, err := am.authorize(ss.Context(), info.FullMethod)
+	if err != nil {
+		return err
+	}
+
+	return handler(srv, &contextServerStream{
+		ServerStream: ss,
+		ctx:          ctx,
+	})
+}
+
+// authorize checks the token and the permission registered for the method in methodPermissions.
+// It returns the context carrying the claims and the permissions of the caller.
+func (am *authMiddleware) authorize(ctx context.Context, fullMethod string) (context.Context, error) {
+	requiredPermission, ok := methodPermissions[fullMethod] // this path get from info.FullMethod
+	if !ok {
+		return nil, status.Errorf(codes.PermissionDenied, "Permission denied")
+	}
+	if requiredPermission == accessPublic {
+		return ctx, nil
+	} // allow login and register without authentication jwt
+
+	// machine clients send an API key instead of a bearer token
+	rawApiKey := utils.ApiKeyFromContext(ctx)
+	if rawApiKey != "" {
+		return am.authorizeApiKey(ctx, fullMethod, requiredPermission, rawApiKey)
+	}
+
+	jwtToken, err := jwtentity.ParseTokenFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	claims, err := am.keySet.GetClaimsFromToken(jwtToken)
+	if err != nil {
+		return nil, err
+	}
+
+	// a token without jti cannot be revoked by logout, so it is not accepted
+	if claims.ID == "" {
+		return nil, utils.UnaunthorizedResponse()
+	}
+	revoked, err := am.tokenRevocationStore.IsRevoked(ctx, claims.ID)
+	if err != nil {
+		return nil, err
+	}
+	if revoked {
+		return nil, utils.UnaunthorizedResponse()
+	}
+
+	// a token outlives neither its session nor the user's account
+	if claims.SessionId == "" {
+		return nil, utils.UnaunthorizedResponse()
+	}
+	userSession, err := am.userService.GetActiveSession(ctx, claims.Subject, claims.SessionId)
+	if err != nil {
+		return nil, err
+	}
+	if userSession == nil {
+		return nil, utils.UnaunthorizedResponse()
+	}
+
+	// disabled users are rejected at once, and role or profile changes apply without waiting for a new token
+	user, err := am.userService.GetActiveUser(ctx, claims.Subject)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, utils.UnaunthorizedResponse()
+	}
+	claims.Role = user.RoleCode
+	claims.Email = user.Email
+	claims.FullName = user.FullName
+	claims.EmailVerified = user.EmailVerifiedAt != nil
+
+	permissions, err := am.permissionService.GetRolePermissions(ctx, claims.Role)
+	if err != nil {
+		return nil, err
+	}
+	if requiredPermission != accessAuthenticated && !permissions[requiredPermission] {
+		return nil, status.Errorf(codes.PermissionDenied, "Permission denied")
+	}
+	// the account can still log in and enroll, only the permissions of its role wait for two-factor authentication
+	if requiredPermission != accessAuthenticated && user.TotpEnabledAt == nil && am.totpPolicy.RequiredFor(user.RoleCode) {
+		return nil, status.Errorf(codes.PermissionDenied, "Enable two-factor authentication to use this method")
+	}
+
+	ctx = claims.SetToContext(ctx) // store the claims in the context
+	logging.SetUserId(ctx, claims.Subject)
+	ctx = entity.SetPermissionsToContext(ctx, permissions)
+	return ctx, nil
+}
+
+// authorizeApiKey lets a service account call the methods in the scopes of its key, within the permissions of its role.
+// The claims carry the service account id as subject and its name as full name.
+func (am *authMiddleware) authorizeApiKey(ctx context.Context, fullMethod string, requiredPermission string, rawApiKey string) (context.Context, error) {
+	serviceAccount, apiKey, err := am.serviceAccountService.AuthenticateApiKey(ctx, rawApiKey)
+	if err != nil {
+		return nil, err
+	}
+	if apiKey == nil {
+		return nil, utils.UnaunthorizedResponse()
+	}
+	// keys created before the scope rules tightened may still list methods that are no longer allowed
+	if !slices.Contains(apiKey.Scopes, fullMethod) || !IsApiKeyScope(fullMethod) {
+		return nil, status.Errorf(codes.PermissionDenied, "API key is not allowed to call this method")
+	}
+
+	permissions, err := am.permissionService.GetRolePermissions(ctx, serviceAccount.RoleCode)
+	if err != nil {
+		return nil, err
+	}
+	if !permissions[requiredPermission] {
+		return nil, status.Errorf(codes.PermissionDenied, "Permission denied")
+	}
+
+	claims := &jwtentity.JwtClaims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject: serviceAccount.Id,
+		},
+		FullName: serviceAccount.Name,
+		Role:     serviceAccount.RoleCode,
+	}
+	ctx = claims.SetToContext(ctx)
+	ctx = entity.SetPermissionsToContext(ctx, permissions)
+	logging.SetUserId(ctx, serviceAccount.Id)
+	return ctx, nil
+}
@@ -0,0 +1,52 @@
+package grpcmiddlerware
+
+import (
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+
+	"github.com/arthurhzna/Golang_gRPC/internal/domainerror"
+	"github.com/arthurhzna/Golang_gRPC/internal/utils"
+)
+
+// domainErrorResponse builds the response message of the method with only its base field set from a domain error.
+// It returns false for other errors and for methods whose response has no BaseResponse base field.
+func domainErrorResponse(fullMethod string, err error) (proto.Message, bool) {
+	domainErr, ok := domainerror.As(err)
+	if !ok {
+		return nil, false
+	}
+
+	// fullMethod is /package.Service/Method
+	serviceName, methodName, ok := strings.Cut(strings.TrimPrefix(fullMethod, "/"), "/")
+	if !ok {
+		return nil, false
+	}
+	descriptor, err := protoregistry.GlobalFiles.FindDescriptorByName(protoreflect.FullName(serviceName))
+	if err != nil {
+		return nil, false
+	}
+	serviceDescriptor, ok := descriptor.(protoreflect.ServiceDescriptor)
+	if !ok {
+		return nil, false
+	}
+	methodDescriptor := serviceDescriptor.Methods().ByName(protoreflect.Name(methodName))
+	if methodDescriptor == nil {
+		return nil, false
+	}
+	messageType, err := protoregistry.GlobalTypes.FindMessageByName(methodDescriptor.Output().FullName())
+	if err != nil {
+		return nil, false
+	}
+
+	baseResponse := utils.DomainErrorResponse(domainErr)
+	message := messageType.New()
+	baseField := message.Descriptor().Fields().ByName("base")
+	if baseField == nil || baseField.Message() == nil || baseField.Message().FullName() != baseResponse.ProtoReflect().Descriptor().FullName() {
+		return nil, false
+	}
+	message.Set(baseField, protoreflect.ValueOfMessage(baseResponse.ProtoReflect()))
+	return message.Interface(), true
+}
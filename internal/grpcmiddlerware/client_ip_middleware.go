@@ -0,0 +1,32 @@
+package grpcmiddlerware
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+
+	"github.com/arthurhzna/Golang_gRPC/internal/utils"
+)
+
+type clientIpMiddleware struct {
+	trustedProxies *utils.TrustedProxies
+}
+
+func NewClientIpMiddleware(trustedProxies *utils.TrustedProxies) *clientIpMiddleware {
+	return &clientIpMiddleware{
+		trustedProxies: trustedProxies,
+	}
+}
+
+// Middleware resolves the client address once, so login limits, sessions and access logs all use the same one.
+// It must run before LoggingMiddleware.
+func (cm *clientIpMiddleware) Middleware(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	return handler(utils.WithClientIp(ctx, cm.trustedProxies.ClientIp(ctx)), req)
+}
+
+func (cm *clientIpMiddleware) StreamMiddleware(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	return handler(srv, &contextServerStream{
+		ServerStream: ss,
+		ctx:          utils.WithClientIp(ss.Context(), cm.trustedProxies.ClientIp(ss.Context())),
+	})
+}
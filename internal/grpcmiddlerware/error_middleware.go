@@ -1,59 +1,120 @@
-package grpcmiddlerware
-
-import (
-	"context"
-	"log"
-	"runtime/debug"
-
-	"google.golang.org/grpc"
-	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/status"
-)
-
-func ErrorMiddleware(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp any, err error) {
-
-	defer func() {
-		if r := recover(); r != nil {
-			log.Printf("Recovered from panic: %v", r)
-			debug.PrintStack() // print the stack trace or panic stack trace
-
-			err = status.Errorf(codes.Internal, "Internal server error: %v", r)
-		}
-
-	}()
-
-	res, err := handler(ctx, req)
-
-	/*
-			Request
-		    ↓
-			┌─────────────────────────────────────────┐
-			│ ErrorMiddleware (start)                 │
-			│ - Setup defer recover                   │
-			│   ↓                                     │
-			│  ┌───────────────────────────────────┐  │
-			│  │ HelloWorld Handler                │  │
-			│  │ - Validation                      │  │
-			│  │ - Process business logic          │  │
-			│  │ - Return response/error           │  │
-			│  └───────────────────────────────────┘  │
-			│   ↓                                     │
-			│ - Handle error if exists                │
-			│ - Wrap error with codes.Internal        │
-			└─────────────────────────────────────────┘
-			↓
-			Response
-	*/
-
-	if err != nil {
-
-		if st, ok := status.FromError(err); ok {
-			if st.Code() == codes.Unauthenticated {
-				return nil, err
-			}
-		}
-		return nil, status.Errorf(codes.Internal, "Internal server error: %v", err)
-		// return nil, err // original error from the handlers
-	}
-	return res, nil
-}
+package grpcmiddlerware
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"runtime/debug"
+
+	"github.com/google/uuid"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/arthurhzna/Golang_gRPC/internal/domainerror"
+	"github.com/arthurhzna/Golang_gRPC/internal/utils"
+)
+
+func ErrorMiddleware(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp any, err error) {
+
+	defer func() {
+		if r := recover(); r != nil {
+			resp = nil
+			err = internalError(ctx, fmt.Errorf("panic: %v", r), slog.String("stack", string(debug.Stack()))) // log the panic stack trace
+		}
+
+	}()
+
+	res, err := handler(ctx, req)
+
+	/*
+			Request
+		    ↓
+			┌─────────────────────────────────────────┐
+			│ ErrorMiddleware (start)                 │
+			│ - Setup defer recover                   │
+			│   ↓                                     │
+			│  ┌───────────────────────────────────┐  │
+			│  │ HelloWorld Handler                │  │
+			│  │ - Validation                      │  │
+			│  │ - Process business logic          │  │
+			│  │ - Return response/error           │  │
+			│  └───────────────────────────────────┘  │
+			│   ↓                                     │
+			│ - Handle error if exists                │
+			│ - Wrap error with codes.Internal        │
+			└─────────────────────────────────────────┘
+			↓
+			Response
+	*/
+
+	if err != nil {
+		// clients that still read BaseResponse get domain errors inside the response, as before
+		if utils.BaseResponseErrorsFromContext(ctx) {
+			if baseResponse, ok := domainErrorResponse(info.FullMethod, err); ok {
+				return baseResponse, nil
+			}
+		}
+		return nil, toGrpcError(ctx, err)
+	}
+	return res, nil
+}
+
+// ErrorStreamMiddleware is ErrorMiddleware for streaming RPCs, a panic in the handler ends the stream with codes.Internal.
+func ErrorStreamMiddleware(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+
+	defer func() {
+		if r := recover(); r != nil {
+			err = internalError(ss.Context(), fmt.Errorf("panic: %v", r), slog.String("stack", string(debug.Stack())))
+		}
+	}()
+
+	err = handler(srv, ss)
+	if err != nil {
+		// the stream ends with a last message carrying the error for clients that read BaseResponse
+		if utils.BaseResponseErrorsFromContext(ss.Context()) {
+			if baseResponse, ok := domainErrorResponse(info.FullMethod, err); ok {
+				return ss.SendMsg(baseResponse)
+			}
+		}
+		return toGrpcError(ss.Context(), err)
+	}
+	return nil
+}
+
+// toGrpcError turns domain errors into their status and keeps status errors made on purpose, such as codes.Unauthenticated.
+// Everything else is an internal error that is logged and hidden from the client.
+func toGrpcError(ctx context.Context, err error) error {
+	if domainErr, ok := domainerror.As(err); ok {
+		return domainErr.GRPCStatus().Err()
+	}
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return status.FromContextError(err).Err()
+	}
+	if st, ok := status.FromError(err); ok && st.Code() != codes.Unknown && st.Code() != codes.Internal {
+		return err
+	}
+	return internalError(ctx, err)
+	// return err // original error from the handlers
+}
+
+// internalError logs err with a new error id and answers codes.Internal with only that id,
+// so a report from a client can be found in the logs without exposing the error.
+func internalError(ctx context.Context, err error, attrs ...slog.Attr) error {
+	errorId := uuid.New().String()
+	attrs = append(attrs, slog.String("error_id", errorId), slog.String("error", err.Error()))
+	slog.LogAttrs(ctx, slog.LevelError, "internal error", attrs...)
+
+	st := status.New(codes.Internal, fmt.Sprintf("Internal server error, error id %s", errorId))
+	stWithDetails, detailsErr := st.WithDetails(&errdetails.ErrorInfo{
+		Reason:   "INTERNAL",
+		Domain:   domainerror.Domain,
+		Metadata: map[string]string{"error_id": errorId},
+	})
+	if detailsErr != nil {
+		return st.Err()
+	}
+	return stWithDetails.Err()
+}
@@ -0,0 +1,65 @@
+package grpcmiddlerware
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/arthurhzna/Golang_gRPC/internal/logging"
+	"github.com/arthurhzna/Golang_gRPC/internal/utils"
+)
+
+// LoggingMiddleware runs right after the client ip middleware: it gives the request its id, sends the id back in the x-request-id header
+// and writes the access log with the final status code.
+func LoggingMiddleware(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	start := time.Now()
+	ctx = withRequestId(ctx, info.FullMethod)
+	grpc.SetHeader(ctx, metadata.Pairs(logging.RequestIdHeader, logging.RequestIdFromContext(ctx)))
+
+	res, err := handler(ctx, req)
+	logAccess(ctx, "grpc request", start, err)
+	return res, err
+}
+
+func LoggingStreamMiddleware(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	start := time.Now()
+	ctx := withRequestId(ss.Context(), info.FullMethod)
+	ss.SetHeader(metadata.Pairs(logging.RequestIdHeader, logging.RequestIdFromContext(ctx)))
+
+	err := handler(srv, &contextServerStream{
+		ServerStream: ss,
+		ctx:          ctx,
+	})
+	logAccess(ctx, "grpc stream", start, err)
+	return err
+}
+
+func withRequestId(ctx context.Context, fullMethod string) context.Context {
+	incoming := ""
+	md, ok := metadata.FromIncomingContext(ctx)
+	if ok {
+		requestIds := md.Get(logging.RequestIdHeader)
+		if len(requestIds) > 0 {
+			incoming = requestIds[0]
+		}
+	}
+	return logging.WithRequest(ctx, logging.RequestId(incoming), fullMethod)
+}
+
+func logAccess(ctx context.Context, message string, start time.Time, err error) {
+	code := status.Code(err)
+	level := slog.LevelInfo
+	if code == codes.Internal || code == codes.Unknown || code == codes.DataLoss {
+		level = slog.LevelError
+	}
+	slog.LogAttrs(ctx, level, message,
+		slog.String("code", code.String()),
+		slog.Int64("duration_ms", time.Since(start).Milliseconds()),
+		slog.String("client_ip", utils.ClientIpFromContext(ctx)),
+	)
+}
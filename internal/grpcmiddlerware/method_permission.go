@@ -0,0 +1,113 @@
+package grpcmiddlerware
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"google.golang.org/grpc"
+
+	"github.com/arthurhzna/Golang_gRPC/internal/entity"
+)
+
+const (
+	// accessPublic methods are served without a token
+	accessPublic = "public"
+	// accessAuthenticated methods need a valid token but no permission, ownership is checked by the service
+	accessAuthenticated = "authenticated"
+)
+
+// methodPermissions maps every gRPC method to the permission it requires.
+// A method missing here is denied, so new RPCs must be registered.
+var methodPermissions = map[string]string{
+	"/auth.AuthService/Register":               accessPublic,
+	"/auth.AuthService/Login":                  accessPublic,
+	"/auth.AuthService/RefreshToken":           accessPublic,
+	"/auth.AuthService/Logout":                 accessAuthenticated,
+	"/auth.AuthService/ChangePassword":         accessAuthenticated,
+	"/auth.AuthService/RequestPasswordReset":   accessPublic,
+	"/auth.AuthService/ResetPassword":          accessPublic,
+	"/auth.AuthService/VerifyEmail":            accessPublic,
+	"/auth.AuthService/ResendVerification":     accessPublic,
+	"/auth.AuthService/UnlockAccount":          entity.PermissionUserManage,
+	"/auth.AuthService/GetProfile":             accessAuthenticated,
+	"/auth.AuthService/UpdateProfile":          accessAuthenticated,
+	"/auth.AuthService/RequestEmailChange":     accessAuthenticated,
+	"/auth.AuthService/ConfirmEmailChange":     accessAuthenticated,
+	"/auth.AuthService/VerifyLoginTotp":        accessPublic,
+	"/auth.AuthService/EnrollTotp":             accessAuthenticated,
+	"/auth.AuthService/ConfirmTotp":            accessAuthenticated,
+	"/auth.AuthService/DisableTotp":            accessAuthenticated,
+	"/auth.AuthService/StartOidcLogin":         accessPublic,
+	"/auth.AuthService/OidcLogin":              accessPublic,
+	"/auth.AuthService/ListSessions":           accessAuthenticated,
+	"/auth.AuthService/RevokeSession":          accessAuthenticated,
+	"/auth.AuthService/RevokeAllOtherSessions": accessAuthenticated,
+
+	"/product.ProductService/DetailProduct":    accessPublic,
+	"/product.ProductService/ListProduct":      accessPublic,
+	"/product.ProductService/HighlightProduct": accessAuthenticated,
+	"/product.ProductService/CreateProduct":    entity.PermissionProductManage,
+	"/product.ProductService/EditProduct":      entity.PermissionProductManage,
+	"/product.ProductService/DeleteProduct":    entity.PermissionProductManage,
+	"/product.ProductService/ListProductAdmin": entity.PermissionProductManage,
+
+	"/cart.CartService/AddProductToCart":   accessAuthenticated,
+	"/cart.CartService/ListCart":           accessAuthenticated,
+	"/cart.CartService/DeleteCart":         accessAuthenticated,
+	"/cart.CartService/UpdateCartQuantity": accessAuthenticated,
+
+	"/order.OrderService/CreateOrder":       accessAuthenticated,
+	"/order.OrderService/CheckoutCart":      accessAuthenticated,
+	"/order.OrderService/ListOrder":         accessAuthenticated,
+	"/order.OrderService/DetailOrder":       accessAuthenticated,
+	"/order.OrderService/UpdateOrderStatus": accessAuthenticated,
+	"/order.OrderService/GetOrderTimeline":  accessAuthenticated,
+	"/order.OrderService/WatchOrder":        accessAuthenticated,
+	"/order.OrderService/ListOrderAdmin":    entity.PermissionOrderReadAll,
+	"/order.OrderService/RefundOrder":       entity.PermissionOrderRefund,
+
+	"/newsletter.NewsletterService/SubscribeNewsletter": accessPublic,
+
+	"/user.UserService/ListUsers":      entity.PermissionUserManage,
+	"/user.UserService/GetUser":        entity.PermissionUserManage,
+	"/user.UserService/ChangeUserRole": entity.PermissionUserManage,
+	"/user.UserService/DisableUser":    entity.PermissionUserManage,
+	"/user.UserService/RestoreUser":    entity.PermissionUserManage,
+
+	"/serviceaccount.ServiceAccountService/CreateServiceAccount": entity.PermissionServiceAccountManage,
+	"/serviceaccount.ServiceAccountService/ListServiceAccounts":  entity.PermissionServiceAccountManage,
+	"/serviceaccount.ServiceAccountService/CreateApiKey":         entity.PermissionServiceAccountManage,
+	"/serviceaccount.ServiceAccountService/ListApiKeys":          entity.PermissionServiceAccountManage,
+	"/serviceaccount.ServiceAccountService/RevokeApiKey":         entity.PermissionServiceAccountManage,
+
+	// only registered when ENVIRONMENT is DEV
+	"/grpc.reflection.v1.ServerReflection/ServerReflectionInfo":      accessPublic,
+	"/grpc.reflection.v1alpha.ServerReflection/ServerReflectionInfo": accessPublic,
+}
+
+// IsApiKeyScope reports whether an API key may be scoped to the method. Only methods gated by a permission qualify:
+// public methods need no key, and authenticated methods act on the data of the caller, which a service account does not own.
+func IsApiKeyScope(fullMethod string) bool {
+	requiredPermission, ok := methodPermissions[fullMethod]
+	return ok && requiredPermission != accessPublic && requiredPermission != accessAuthenticated
+}
+
+// CheckMethodPermissions returns an error naming every method served by the server, unary or streaming,
+// that is missing from methodPermissions. Both interceptor chains deny such methods, so the server should not start.
+func CheckMethodPermissions(server *grpc.Server) error {
+	var missing []string
+	for serviceName, serviceInfo := range server.GetServiceInfo() {
+		for _, method := range serviceInfo.Methods {
+			fullMethod := "/" + serviceName + "/" + method.Name
+			if _, ok := methodPermissions[fullMethod]; !ok {
+				missing = append(missing, fullMethod)
+			}
+		}
+	}
+	if len(missing) > 0 {
+		sort.Strings(missing)
+		return fmt.Errorf("methods missing from methodPermissions: %s", strings.Join(missing, ", "))
+	}
+	return nil
+}
@@ -0,0 +1,45 @@
+package grpcmiddlerware
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/arthurhzna/Golang_gRPC/internal/utils"
+)
+
+// ValidationMiddleware checks the protovalidate rules of every request before the handler runs,
+// so handlers can trust their request. It runs after the auth middleware so anonymous callers learn nothing from it.
+func ValidationMiddleware(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	if message, ok := req.(proto.Message); ok {
+		err := utils.ValidateRequest(message)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return handler(ctx, req)
+}
+
+// validatingServerStream checks every message the client sends on a stream, including the request of a server stream
+type validatingServerStream struct {
+	grpc.ServerStream
+}
+
+func (vs *validatingServerStream) RecvMsg(m any) error {
+	err := vs.ServerStream.RecvMsg(m)
+	if err != nil {
+		return err
+	}
+	if message, ok := m.(proto.Message); ok {
+		return utils.ValidateRequest(message)
+	}
+	return nil
+}
+
+func ValidationStreamMiddleware(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	return handler(srv, &validatingServerStream{
+		ServerStream: ss,
+	})
+}
@@ -1,110 +1,227 @@
-package handler
-
-import (
-	"context"
-
-	"github.com/arthurhzna/Golang_gRPC/internal/service"
-	"github.com/arthurhzna/Golang_gRPC/internal/utils"
-	"github.com/arthurhzna/Golang_gRPC/pb/auth"
-)
-
-type authHandler struct {
-	auth.UnimplementedAuthServiceServer // parent struct from the package auth gengerate by protobuf
-
-	authService service.IAuthService
-}
-
-func NewAuthHandler(authService service.IAuthService) *authHandler {
-	return &authHandler{
-		authService: authService,
-	}
-}
-
-func (sh *authHandler) Register(ctx context.Context, req *auth.RegisterRequest) (*auth.RegisterResponse, error) {
-
-	validationErrors, err := utils.CheckValidation(req)
-	if err != nil {
-		return nil, err
-	}
-
-	if validationErrors != nil {
-		return &auth.RegisterResponse{
-			Base: utils.ValidationErrorResponse(validationErrors),
-		}, nil
-	}
-
-	res, err := sh.authService.Register(ctx, req)
-	if err != nil {
-		return nil, err
-	}
-	return res, nil
-}
-
-func (sh *authHandler) Login(ctx context.Context, req *auth.LoginRequest) (*auth.LoginResponse, error) {
-
-	validationErrors, err := utils.CheckValidation(req)
-	if err != nil {
-		return nil, err
-	}
-
-	if validationErrors != nil {
-		return &auth.LoginResponse{
-			Base: utils.ValidationErrorResponse(validationErrors),
-		}, nil
-	}
-
-	res, err := sh.authService.Login(ctx, req)
-	if err != nil {
-		return nil, err
-	}
-	return res, nil
-}
-
-func (sh *authHandler) Logout(ctx context.Context, req *auth.LogoutRequest) (*auth.LogoutResponse, error) {
-
-	validationErrors, err := utils.CheckValidation(req)
-	if err != nil {
-		return nil, err
-	}
-
-	if validationErrors != nil {
-		return &auth.LogoutResponse{
-			Base: utils.ValidationErrorResponse(validationErrors),
-		}, nil
-	}
-
-	res, err := sh.authService.Logout(ctx, req)
-	if err != nil {
-		return nil, err
-	}
-	return res, nil
-}
-
-func (sh *authHandler) ChangePassword(ctx context.Context, req *auth.ChangePasswordRequest) (*auth.ChangePasswordResponse, error) {
-
-	validationErrors, err := utils.CheckValidation(req)
-	if err != nil {
-		return nil, err
-	}
-
-	if validationErrors != nil {
-		return &auth.ChangePasswordResponse{
-			Base: utils.ValidationErrorResponse(validationErrors),
-		}, nil
-	}
-
-	res, err := sh.authService.ChangePassword(ctx, req)
-	if err != nil {
-		return nil, err
-	}
-	return res, nil
-}
-
-func (sh *authHandler) GetProfile(ctx context.Context, req *auth.GetProfileRequest) (*auth.GetProfileResponse, error) {
-
-	res, err := sh.authService.GetProfile(ctx, req)
-	if err != nil {
-		return nil, err
-	}
-	return res, nil
-}
+package handler
+
+import (
+	"context"
+
+	"github.com/arthurhzna/Golang_gRPC/internal/service"
+	"github.com/arthurhzna/Golang_gRPC/pb/auth"
+)
+
+type authHandler struct {
+	auth.UnimplementedAuthServiceServer // parent struct from the package auth gengerate by protobuf
+
+	authService service.IAuthService
+}
+
+func NewAuthHandler(authService service.IAuthService) *authHandler {
+	return &authHandler{
+		authService: authService,
+	}
+}
+
+func (sh *authHandler) Register(ctx context.Context, req *auth.RegisterRequest) (*auth.RegisterResponse, error) {
+
+	res, err := sh.authService.Register(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (sh *authHandler) Login(ctx context.Context, req *auth.LoginRequest) (*auth.LoginResponse, error) {
+
+	res, err := sh.authService.Login(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (sh *authHandler) RefreshToken(ctx context.Context, req *auth.RefreshTokenRequest) (*auth.RefreshTokenResponse, error) {
+
+	res, err := sh.authService.RefreshToken(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (sh *authHandler) Logout(ctx context.Context, req *auth.LogoutRequest) (*auth.LogoutResponse, error) {
+
+	res, err := sh.authService.Logout(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (sh *authHandler) ChangePassword(ctx context.Context, req *auth.ChangePasswordRequest) (*auth.ChangePasswordResponse, error) {
+
+	res, err := sh.authService.ChangePassword(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (sh *authHandler) RequestPasswordReset(ctx context.Context, req *auth.RequestPasswordResetRequest) (*auth.RequestPasswordResetResponse, error) {
+
+	res, err := sh.authService.RequestPasswordReset(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (sh *authHandler) ResetPassword(ctx context.Context, req *auth.ResetPasswordRequest) (*auth.ResetPasswordResponse, error) {
+
+	res, err := sh.authService.ResetPassword(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (sh *authHandler) VerifyEmail(ctx context.Context, req *auth.VerifyEmailRequest) (*auth.VerifyEmailResponse, error) {
+
+	res, err := sh.authService.VerifyEmail(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (sh *authHandler) ResendVerification(ctx context.Context, req *auth.ResendVerificationRequest) (*auth.ResendVerificationResponse, error) {
+
+	res, err := sh.authService.ResendVerification(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (sh *authHandler) UnlockAccount(ctx context.Context, req *auth.UnlockAccountRequest) (*auth.UnlockAccountResponse, error) {
+
+	res, err := sh.authService.UnlockAccount(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (sh *authHandler) GetProfile(ctx context.Context, req *auth.GetProfileRequest) (*auth.GetProfileResponse, error) {
+
+	res, err := sh.authService.GetProfile(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (sh *authHandler) UpdateProfile(ctx context.Context, req *auth.UpdateProfileRequest) (*auth.UpdateProfileResponse, error) {
+
+	res, err := sh.authService.UpdateProfile(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (sh *authHandler) RequestEmailChange(ctx context.Context, req *auth.RequestEmailChangeRequest) (*auth.RequestEmailChangeResponse, error) {
+
+	res, err := sh.authService.RequestEmailChange(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (sh *authHandler) ConfirmEmailChange(ctx context.Context, req *auth.ConfirmEmailChangeRequest) (*auth.ConfirmEmailChangeResponse, error) {
+
+	res, err := sh.authService.ConfirmEmailChange(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (sh *authHandler) VerifyLoginTotp(ctx context.Context, req *auth.VerifyLoginTotpRequest) (*auth.VerifyLoginTotpResponse, error) {
+
+	res, err := sh.authService.VerifyLoginTotp(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (sh *authHandler) EnrollTotp(ctx context.Context, req *auth.EnrollTotpRequest) (*auth.EnrollTotpResponse, error) {
+
+	res, err := sh.authService.EnrollTotp(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (sh *authHandler) ConfirmTotp(ctx context.Context, req *auth.ConfirmTotpRequest) (*auth.ConfirmTotpResponse, error) {
+
+	res, err := sh.authService.ConfirmTotp(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (sh *authHandler) DisableTotp(ctx context.Context, req *auth.DisableTotpRequest) (*auth.DisableTotpResponse, error) {
+
+	res, err := sh.authService.DisableTotp(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (sh *authHandler) StartOidcLogin(ctx context.Context, req *auth.StartOidcLoginRequest) (*auth.StartOidcLoginResponse, error) {
+
+	res, err := sh.authService.StartOidcLogin(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (sh *authHandler) OidcLogin(ctx context.Context, req *auth.OidcLoginRequest) (*auth.OidcLoginResponse, error) {
+
+	res, err := sh.authService.OidcLogin(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (sh *authHandler) ListSessions(ctx context.Context, req *auth.ListSessionsRequest) (*auth.ListSessionsResponse, error) {
+
+	res, err := sh.authService.ListSessions(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (sh *authHandler) RevokeSession(ctx context.Context, req *auth.RevokeSessionRequest) (*auth.RevokeSessionResponse, error) {
+
+	res, err := sh.authService.RevokeSession(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (sh *authHandler) RevokeAllOtherSessions(ctx context.Context, req *auth.RevokeAllOtherSessionsRequest) (*auth.RevokeAllOtherSessionsResponse, error) {
+
+	res, err := sh.authService.RevokeAllOtherSessions(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
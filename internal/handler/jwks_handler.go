@@ -0,0 +1,22 @@
+package handler
+
+import (
+	jwtentity "github.com/arthurhzna/Golang_gRPC/internal/entity/jwt"
+	"github.com/gofiber/fiber/v2"
+)
+
+type jwksHandler struct {
+	keySet *jwtentity.KeySet
+}
+
+func NewJwksHandler(keySet *jwtentity.KeySet) *jwksHandler {
+	return &jwksHandler{
+		keySet: keySet,
+	}
+}
+
+// GetJwks serves the public verification keys so other services can verify access tokens.
+func (jh *jwksHandler) GetJwks(c *fiber.Ctx) error {
+	c.Set("Cache-Control", "public, max-age=300")
+	return c.JSON(jh.keySet.Jwks())
+}
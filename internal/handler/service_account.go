@@ -0,0 +1,65 @@
+package handler
+
+import (
+	"context"
+
+	"github.com/arthurhzna/Golang_gRPC/internal/service"
+	"github.com/arthurhzna/Golang_gRPC/pb/serviceaccount"
+)
+
+type serviceAccountHandler struct {
+	serviceaccount.UnimplementedServiceAccountServiceServer
+
+	serviceAccountService service.IServiceAccountService
+}
+
+func NewServiceAccountHandler(serviceAccountService service.IServiceAccountService) *serviceAccountHandler {
+	return &serviceAccountHandler{
+		serviceAccountService: serviceAccountService,
+	}
+}
+
+func (sah *serviceAccountHandler) CreateServiceAccount(ctx context.Context, req *serviceaccount.CreateServiceAccountRequest) (*serviceaccount.CreateServiceAccountResponse, error) {
+
+	res, err := sah.serviceAccountService.CreateServiceAccount(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (sah *serviceAccountHandler) ListServiceAccounts(ctx context.Context, req *serviceaccount.ListServiceAccountsRequest) (*serviceaccount.ListServiceAccountsResponse, error) {
+
+	res, err := sah.serviceAccountService.ListServiceAccounts(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (sah *serviceAccountHandler) CreateApiKey(ctx context.Context, req *serviceaccount.CreateApiKeyRequest) (*serviceaccount.CreateApiKeyResponse, error) {
+
+	res, err := sah.serviceAccountService.CreateApiKey(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (sah *serviceAccountHandler) ListApiKeys(ctx context.Context, req *serviceaccount.ListApiKeysRequest) (*serviceaccount.ListApiKeysResponse, error) {
+
+	res, err := sah.serviceAccountService.ListApiKeys(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (sah *serviceAccountHandler) RevokeApiKey(ctx context.Context, req *serviceaccount.RevokeApiKeyRequest) (*serviceaccount.RevokeApiKeyResponse, error) {
+
+	res, err := sah.serviceAccountService.RevokeApiKey(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
@@ -0,0 +1,65 @@
+package handler
+
+import (
+	"context"
+
+	"github.com/arthurhzna/Golang_gRPC/internal/service"
+	"github.com/arthurhzna/Golang_gRPC/pb/user"
+)
+
+type userHandler struct {
+	user.UnimplementedUserServiceServer
+
+	userService service.IUserService
+}
+
+func NewUserHandler(userService service.IUserService) *userHandler {
+	return &userHandler{
+		userService: userService,
+	}
+}
+
+func (uh *userHandler) ListUsers(ctx context.Context, req *user.ListUsersRequest) (*user.ListUsersResponse, error) {
+
+	res, err := uh.userService.ListUsers(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (uh *userHandler) GetUser(ctx context.Context, req *user.GetUserRequest) (*user.GetUserResponse, error) {
+
+	res, err := uh.userService.GetUser(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (uh *userHandler) ChangeUserRole(ctx context.Context, req *user.ChangeUserRoleRequest) (*user.ChangeUserRoleResponse, error) {
+
+	res, err := uh.userService.ChangeUserRole(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (uh *userHandler) DisableUser(ctx context.Context, req *user.DisableUserRequest) (*user.DisableUserResponse, error) {
+
+	res, err := uh.userService.DisableUser(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (uh *userHandler) RestoreUser(ctx context.Context, req *user.RestoreUserRequest) (*user.RestoreUserResponse, error) {
+
+	res, err := uh.userService.RestoreUser(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
@@ -1,37 +1,46 @@
-package handler
-
-import (
-	"log"
-	"net/http"
-
-	"github.com/arthurhzna/Golang_gRPC/internal/dto"
-	"github.com/arthurhzna/Golang_gRPC/internal/service"
-	"github.com/gofiber/fiber/v2"
-)
-
-type webhookHandler struct {
-	webhookService service.IWebhookService
-}
-
-func NewWebhookHandler(webhookService service.IWebhookService) *webhookHandler {
-	return &webhookHandler{
-		webhookService: webhookService,
-	}
-}
-
-func (wh *webhookHandler) ReceiveInvoice(c *fiber.Ctx) error {
-	var request dto.XenditInvoiceRequest
-
-	err := c.BodyParser(&request)
-	if err != nil {
-		log.Println(err)
-		return c.SendStatus(http.StatusBadRequest)
-	}
-
-	err = wh.webhookService.ReceiveInvoice(c.Context(), &request)
-	if err != nil {
-		log.Println(err)
-		return c.SendStatus(http.StatusInternalServerError)
-	}
-	return c.SendStatus(http.StatusOK)
-}
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/arthurhzna/Golang_gRPC/internal/payment"
+	"github.com/arthurhzna/Golang_gRPC/internal/service"
+	"github.com/gofiber/fiber/v2"
+)
+
+type webhookHandler struct {
+	webhookService service.IWebhookService
+}
+
+func NewWebhookHandler(webhookService service.IWebhookService) *webhookHandler {
+	return &webhookHandler{
+		webhookService: webhookService,
+	}
+}
+
+func (wh *webhookHandler) ReceiveInvoice(c *fiber.Ctx) error {
+	header := http.Header{}
+	for key, values := range c.GetReqHeaders() {
+		for _, value := range values {
+			header.Add(key, value)
+		}
+	}
+
+	err := wh.webhookService.ReceiveInvoice(c.UserContext(), header, c.Body())
+	if err != nil {
+		slog.ErrorContext(c.UserContext(), "Receive invoice webhook failed", "error", err)
+		if errors.Is(err, payment.ErrInvalidCallback) {
+			return c.SendStatus(http.StatusUnauthorized)
+		}
+		var syntaxErr *json.SyntaxError
+		var typeErr *json.UnmarshalTypeError
+		if errors.As(err, &syntaxErr) || errors.As(err, &typeErr) {
+			return c.SendStatus(http.StatusBadRequest)
+		}
+		return c.SendStatus(http.StatusInternalServerError)
+	}
+	return c.SendStatus(http.StatusOK)
+}
@@ -0,0 +1,88 @@
+package logging
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+
+	"github.com/google/uuid"
+)
+
+// RequestIdHeader is the gRPC metadata key and HTTP header of the request id, it is accepted from clients and sent back.
+const RequestIdHeader = "x-request-id"
+
+const requestIdMaxLength = 128
+
+type requestInfoKey struct{}
+
+// requestInfo is shared by the whole request, so the user id found by the auth middleware deep in the chain
+// is also seen by the access log written at the start of it.
+type requestInfo struct {
+	requestId string
+	method    string
+
+	mu     sync.RWMutex
+	userId string
+}
+
+// WithRequest returns a context carrying the request id and the method (gRPC full method or HTTP route) of a request.
+func WithRequest(ctx context.Context, requestId string, method string) context.Context {
+	return context.WithValue(ctx, requestInfoKey{}, &requestInfo{
+		requestId: requestId,
+		method:    method,
+	})
+}
+
+// SetUserId records the caller once it is authenticated, it does nothing outside of a request.
+func SetUserId(ctx context.Context, userId string) {
+	info, ok := ctx.Value(requestInfoKey{}).(*requestInfo)
+	if !ok {
+		return
+	}
+	info.mu.Lock()
+	info.userId = userId
+	info.mu.Unlock()
+}
+
+func RequestIdFromContext(ctx context.Context) string {
+	info, ok := ctx.Value(requestInfoKey{}).(*requestInfo)
+	if !ok {
+		return ""
+	}
+	return info.requestId
+}
+
+// RequestId keeps the id sent by the client so a request can be followed across services,
+// and generates one when it is missing or not a short printable token.
+func RequestId(incoming string) string {
+	if incoming == "" || len(incoming) > requestIdMaxLength {
+		return uuid.New().String()
+	}
+	for _, r := range incoming {
+		if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '-' || r == '_' || r == '.' || r == ':') {
+			return uuid.New().String()
+		}
+	}
+	return incoming
+}
+
+func requestAttrs(ctx context.Context) []slog.Attr {
+	if ctx == nil {
+		return nil
+	}
+	info, ok := ctx.Value(requestInfoKey{}).(*requestInfo)
+	if !ok {
+		return nil
+	}
+	attrs := []slog.Attr{
+		slog.String("request_id", info.requestId),
+		slog.String("method", info.method),
+	}
+	info.mu.RLock()
+	userId := info.userId
+	info.mu.RUnlock()
+	if userId != "" {
+		attrs = append(attrs, slog.String("user_id", userId))
+	}
+	return attrs
+}
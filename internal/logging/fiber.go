@@ -0,0 +1,39 @@
+package logging
+
+import (
+	"log/slog"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// FiberMiddleware gives every REST request a request id, sent back in X-Request-Id, puts it in c.UserContext()
+// for the handlers and writes an access log once the response is ready.
+func FiberMiddleware(c *fiber.Ctx) error {
+	start := time.Now()
+	requestId := RequestId(c.Get(RequestIdHeader))
+	ctx := WithRequest(c.UserContext(), requestId, c.Method()+" "+c.Path())
+	c.SetUserContext(ctx)
+	c.Set(RequestIdHeader, requestId)
+
+	err := c.Next()
+	if err != nil {
+		// let the error handler write the response first, so the logged status is the one sent
+		handlerErr := c.App().ErrorHandler(c, err)
+		if handlerErr != nil {
+			c.Status(fiber.StatusInternalServerError)
+		}
+	}
+
+	status := c.Response().StatusCode()
+	level := slog.LevelInfo
+	if status >= fiber.StatusInternalServerError {
+		level = slog.LevelError
+	}
+	slog.LogAttrs(ctx, level, "http request",
+		slog.Int("status", status),
+		slog.Int64("duration_ms", time.Since(start).Milliseconds()),
+		slog.String("client_ip", c.IP()),
+	)
+	return nil
+}
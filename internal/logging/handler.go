@@ -0,0 +1,24 @@
+package logging
+
+import (
+	"context"
+	"log/slog"
+)
+
+// contextHandler adds the request id, method and user id of the context to every record
+type contextHandler struct {
+	slog.Handler
+}
+
+func (ch *contextHandler) Handle(ctx context.Context, record slog.Record) error {
+	record.AddAttrs(requestAttrs(ctx)...)
+	return ch.Handler.Handle(ctx, record)
+}
+
+func (ch *contextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &contextHandler{Handler: ch.Handler.WithAttrs(attrs)}
+}
+
+func (ch *contextHandler) WithGroup(name string) slog.Handler {
+	return &contextHandler{Handler: ch.Handler.WithGroup(name)}
+}
@@ -0,0 +1,30 @@
+// Package logging sets up the JSON slog logger and carries the request id, method and user id of a request
+// in its context, so every log line written with the request context names the request it belongs to.
+package logging
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+)
+
+// NewLoggerFromEnv returns a JSON logger writing to stdout at LOG_LEVEL (debug, info, warn or error, info by default).
+func NewLoggerFromEnv() (*slog.Logger, error) {
+	var level slog.Level
+	switch strings.ToLower(os.Getenv("LOG_LEVEL")) {
+	case "debug":
+		level = slog.LevelDebug
+	case "", "info":
+		level = slog.LevelInfo
+	case "warn":
+		level = slog.LevelWarn
+	case "error":
+		level = slog.LevelError
+	default:
+		return nil, fmt.Errorf("unknown log level %q", os.Getenv("LOG_LEVEL"))
+	}
+
+	jsonHandler := slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: level})
+	return slog.New(&contextHandler{Handler: jsonHandler}), nil
+}
@@ -0,0 +1,34 @@
+package mailer
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// fileMailer writes every email as a .eml file in dir, so they can be opened with a mail client.
+type fileMailer struct {
+	dir string
+}
+
+func NewFileMailer(dir string) (Mailer, error) {
+	if dir == "" {
+		dir = filepath.Join("storage", "mail")
+	}
+	err := os.MkdirAll(dir, 0o755)
+	if err != nil {
+		return nil, err
+	}
+	return &fileMailer{dir: dir}, nil
+}
+
+func (fm *fileMailer) Send(ctx context.Context, message *Message) error {
+	now := time.Now()
+	fileName := fmt.Sprintf("%s_%s.eml", now.Format("20060102T150405"), uuid.New().String())
+	content := fmt.Sprintf("Date: %s\r\nTo: %s\r\nSubject: %s\r\n\r\n%s\r\n", now.Format(time.RFC1123Z), message.To, message.Subject, message.Body)
+	return os.WriteFile(filepath.Join(fm.dir, fileName), []byte(content), 0o644)
+}
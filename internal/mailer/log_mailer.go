@@ -0,0 +1,20 @@
+package mailer
+
+import (
+	"context"
+	"log/slog"
+)
+
+// logMailer writes emails to the process log instead of sending them. The body carries the links with their tokens,
+// so it is only logged at debug level.
+type logMailer struct{}
+
+func NewLogMailer() Mailer {
+	return &logMailer{}
+}
+
+func (lm *logMailer) Send(ctx context.Context, message *Message) error {
+	slog.InfoContext(ctx, "Mail", "to", message.To, "subject", message.Subject)
+	slog.DebugContext(ctx, "Mail body", "to", message.To, "body", message.Body)
+	return nil
+}
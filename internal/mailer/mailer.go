@@ -0,0 +1,44 @@
+package mailer
+
+import (
+	"context"
+	"fmt"
+	"os"
+)
+
+const (
+	MailerLog  = "log"
+	MailerFile = "file"
+	MailerSmtp = "smtp"
+)
+
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// Mailer sends transactional emails such as password reset links.
+type Mailer interface {
+	Send(ctx context.Context, message *Message) error
+}
+
+// NewMailerFromEnv builds the mailer selected by MAILER, the log mailer when it is not set.
+// The log and file mailers never send anything and are meant for local development.
+func NewMailerFromEnv() (Mailer, error) {
+	switch os.Getenv("MAILER") {
+	case "", MailerLog:
+		return NewLogMailer(), nil
+	case MailerFile:
+		return NewFileMailer(os.Getenv("MAILER_DIR"))
+	case MailerSmtp:
+		return NewSmtpMailer(
+			os.Getenv("SMTP_HOST"),
+			os.Getenv("SMTP_PORT"),
+			os.Getenv("SMTP_USERNAME"),
+			os.Getenv("SMTP_PASSWORD"),
+			os.Getenv("MAIL_FROM"),
+		), nil
+	}
+	return nil, fmt.Errorf("unknown mailer %q", os.Getenv("MAILER"))
+}
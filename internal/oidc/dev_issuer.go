@@ -0,0 +1,212 @@
+package oidc
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"html"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	devIssuerKeyId        = "dev-issuer"
+	devIssuerCodeDuration = time.Minute
+	devIssuerIdTokenTtl   = time.Minute * 5
+)
+
+// devAuthorization is an authorization code waiting to be redeemed at the token endpoint.
+type devAuthorization struct {
+	email         string
+	clientId      string
+	redirectUri   string
+	nonce         string
+	codeChallenge string
+	expiresAt     time.Time
+}
+
+// DevIssuer is a stand-in OpenID Connect issuer for local development. It signs in whatever email is typed
+// on its authorize page, so it must never be configured in production.
+type DevIssuer struct {
+	issuerUrl  string
+	privateKey *rsa.PrivateKey
+
+	mu             sync.Mutex
+	authorizations map[string]*devAuthorization
+}
+
+// NewDevIssuer creates an issuer with a fresh signing key, tokens of an earlier run do not verify anymore.
+func NewDevIssuer(issuerUrl string) (*DevIssuer, error) {
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		return nil, err
+	}
+	return &DevIssuer{
+		issuerUrl:      strings.TrimSuffix(issuerUrl, "/"),
+		privateKey:     privateKey,
+		authorizations: make(map[string]*devAuthorization),
+	}, nil
+}
+
+func (di *DevIssuer) Register(app *fiber.App) {
+	app.Get("/.well-known/openid-configuration", di.discovery)
+	app.Get("/jwks", di.jwks)
+	app.Get("/authorize", di.authorize)
+	app.Post("/token", di.token)
+}
+
+func (di *DevIssuer) discovery(c *fiber.Ctx) error {
+	return c.JSON(fiber.Map{
+		"issuer":                                di.issuerUrl,
+		"authorization_endpoint":                di.issuerUrl + "/authorize",
+		"token_endpoint":                        di.issuerUrl + "/token",
+		"jwks_uri":                              di.issuerUrl + "/jwks",
+		"response_types_supported":              []string{"code"},
+		"subject_types_supported":               []string{"public"},
+		"id_token_signing_alg_values_supported": []string{"RS256"},
+		"code_challenge_methods_supported":      []string{"S256"},
+	})
+}
+
+func (di *DevIssuer) jwks(c *fiber.Ctx) error {
+	return c.JSON(fiber.Map{
+		"keys": []fiber.Map{{
+			"kty": "RSA",
+			"kid": devIssuerKeyId,
+			"use": "sig",
+			"alg": "RS256",
+			"n":   base64.RawURLEncoding.EncodeToString(di.privateKey.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(di.privateKey.E)).Bytes()),
+		}},
+	})
+}
+
+// authorize asks for an email, or signs it in at once when it is passed as login_hint.
+func (di *DevIssuer) authorize(c *fiber.Ctx) error {
+	redirectUri := c.Query("redirect_uri")
+	if c.Query("response_type") != "code" || c.Query("client_id") == "" || redirectUri == "" {
+		return c.Status(http.StatusBadRequest).SendString("response_type=code, client_id and redirect_uri are required")
+	}
+	if c.Query("code_challenge_method") != "S256" || c.Query("code_challenge") == "" {
+		return c.Status(http.StatusBadRequest).SendString("a S256 code_challenge is required")
+	}
+
+	email := strings.TrimSpace(c.Query("login_hint"))
+	if email == "" {
+		var hiddenInputs strings.Builder
+		for key, value := range c.Queries() {
+			hiddenInputs.WriteString(`<input type="hidden" name="` + html.EscapeString(key) + `" value="` + html.EscapeString(value) + `">`)
+		}
+		c.Set(fiber.HeaderContentType, fiber.MIMETextHTMLCharsetUTF8)
+		return c.SendString(`<!doctype html><title>Dev issuer</title><form method="get" action="/authorize">` + hiddenInputs.String() +
+			`<label>Sign in as <input type="email" name="login_hint" required autofocus></label> <button type="submit">Continue</button></form>`)
+	}
+
+	codeBytes := make([]byte, 32)
+	_, err := rand.Read(codeBytes)
+	if err != nil {
+		return err
+	}
+	code := base64.RawURLEncoding.EncodeToString(codeBytes)
+
+	di.mu.Lock()
+	di.authorizations[code] = &devAuthorization{
+		email:         email,
+		clientId:      c.Query("client_id"),
+		redirectUri:   redirectUri,
+		nonce:         c.Query("nonce"),
+		codeChallenge: c.Query("code_challenge"),
+		expiresAt:     time.Now().Add(devIssuerCodeDuration),
+	}
+	di.mu.Unlock()
+
+	callbackUrl, err := url.Parse(redirectUri)
+	if err != nil {
+		return c.Status(http.StatusBadRequest).SendString("invalid redirect_uri")
+	}
+	query := callbackUrl.Query()
+	query.Set("code", code)
+	query.Set("state", c.Query("state"))
+	callbackUrl.RawQuery = query.Encode()
+	return c.Redirect(callbackUrl.String(), http.StatusFound)
+}
+
+func (di *DevIssuer) token(c *fiber.Ctx) error {
+	if c.FormValue("grant_type") != "authorization_code" {
+		return c.Status(http.StatusBadRequest).JSON(fiber.Map{"error": "unsupported_grant_type"})
+	}
+
+	code := c.FormValue("code")
+	di.mu.Lock()
+	authorization := di.authorizations[code]
+	delete(di.authorizations, code)
+	di.mu.Unlock()
+
+	// the client id comes from basic auth or the form, any client secret is accepted
+	clientId := c.FormValue("client_id")
+	if basicClientId, _, ok := basicAuth(c.Get(fiber.HeaderAuthorization)); ok {
+		clientId = basicClientId
+	}
+
+	verifierHash := sha256.Sum256([]byte(c.FormValue("code_verifier")))
+	if authorization == nil || time.Now().After(authorization.expiresAt) || authorization.clientId != clientId ||
+		authorization.redirectUri != c.FormValue("redirect_uri") ||
+		base64.RawURLEncoding.EncodeToString(verifierHash[:]) != authorization.codeChallenge {
+		return c.Status(http.StatusBadRequest).JSON(fiber.Map{"error": "invalid_grant"})
+	}
+
+	now := time.Now()
+	subjectHash := sha256.Sum256([]byte(strings.ToLower(authorization.email)))
+	idToken := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.MapClaims{
+		"iss":            di.issuerUrl,
+		"sub":            hex.EncodeToString(subjectHash[:16]),
+		"aud":            authorization.clientId,
+		"iat":            now.Unix(),
+		"exp":            now.Add(devIssuerIdTokenTtl).Unix(),
+		"nonce":          authorization.nonce,
+		"email":          authorization.email,
+		"email_verified": true,
+		"name":           strings.Split(authorization.email, "@")[0],
+	})
+	idToken.Header["kid"] = devIssuerKeyId
+	signedIdToken, err := idToken.SignedString(di.privateKey)
+	if err != nil {
+		return err
+	}
+
+	accessTokenBytes := make([]byte, 32)
+	_, err = rand.Read(accessTokenBytes)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(fiber.Map{
+		"access_token": base64.RawURLEncoding.EncodeToString(accessTokenBytes),
+		"token_type":   "Bearer",
+		"expires_in":   int(devIssuerIdTokenTtl.Seconds()),
+		"id_token":     signedIdToken,
+	})
+}
+
+func basicAuth(header string) (string, string, bool) {
+	request := http.Request{Header: http.Header{"Authorization": {header}}}
+	username, password, ok := request.BasicAuth()
+	if !ok {
+		return "", "", false
+	}
+	// client credentials are form encoded inside the basic auth header
+	username, err := url.QueryUnescape(username)
+	if err != nil {
+		return "", "", false
+	}
+	return username, password, true
+}
@@ -0,0 +1,189 @@
+package oidc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+
+	gooidc "github.com/coreos/go-oidc/v3/oidc"
+	"golang.org/x/oauth2"
+)
+
+var ErrMissingIdToken = errors.New("token response has no id_token")
+
+// Identity is what the provider asserts about the user in a verified ID token.
+type Identity struct {
+	Subject       string
+	Email         string
+	EmailVerified bool
+	Name          string
+}
+
+type ProviderConfig struct {
+	IssuerUrl    string
+	ClientId     string
+	ClientSecret string
+	RedirectUrl  string
+	// JwksUrl replaces the jwks_uri of the discovery document when set
+	JwksUrl string
+	// LinkExistingAccounts lets a first login attach to the account registered with the same email. Only enable it
+	// for issuers that own the email addresses they vouch for
+	LinkExistingAccounts bool
+}
+
+// Provider signs users in with the authorization code flow of one OpenID Connect issuer.
+type Provider interface {
+	Name() string
+	LinkExistingAccounts() bool
+	AuthCodeUrl(ctx context.Context, state string, nonce string, codeVerifier string) (string, error)
+	// Exchange redeems the authorization code and verifies the ID token against the issuer keys and the nonce.
+	Exchange(ctx context.Context, code string, nonce string, codeVerifier string) (*Identity, error)
+}
+
+type oidcProvider struct {
+	name   string
+	config ProviderConfig
+
+	// mu guards the discovered endpoints, they stay nil until the issuer answered once
+	mu           sync.Mutex
+	oauth2Config *oauth2.Config
+	verifier     *gooidc.IDTokenVerifier
+}
+
+// NewProvider does not contact the issuer, the discovery document is read on first use and retried until it succeeds,
+// so an unreachable issuer only fails its own logins.
+func NewProvider(name string, config ProviderConfig) Provider {
+	return &oidcProvider{
+		name:   name,
+		config: config,
+	}
+}
+
+// NewProvidersFromEnv reads OIDC_PROVIDERS, a comma separated list of provider names, and for each name
+// OIDC_<NAME>_ISSUER_URL, OIDC_<NAME>_CLIENT_ID, OIDC_<NAME>_CLIENT_SECRET, OIDC_<NAME>_REDIRECT_URL and the optional
+// OIDC_<NAME>_JWKS_URL and OIDC_<NAME>_LINK_EXISTING_ACCOUNTS, which defaults to false.
+func NewProvidersFromEnv() (map[string]Provider, error) {
+	providers := make(map[string]Provider)
+	for _, name := range strings.Split(os.Getenv("OIDC_PROVIDERS"), ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+
+		envPrefix := "OIDC_" + strings.ToUpper(name) + "_"
+		config := ProviderConfig{
+			IssuerUrl:    os.Getenv(envPrefix + "ISSUER_URL"),
+			ClientId:     os.Getenv(envPrefix + "CLIENT_ID"),
+			ClientSecret: os.Getenv(envPrefix + "CLIENT_SECRET"),
+			RedirectUrl:  os.Getenv(envPrefix + "REDIRECT_URL"),
+			JwksUrl:      os.Getenv(envPrefix + "JWKS_URL"),
+		}
+		if config.IssuerUrl == "" || config.ClientId == "" || config.RedirectUrl == "" {
+			return nil, fmt.Errorf("oidc provider %s needs %sISSUER_URL, %sCLIENT_ID and %sREDIRECT_URL", name, envPrefix, envPrefix, envPrefix)
+		}
+		if linkExistingAccounts := os.Getenv(envPrefix + "LINK_EXISTING_ACCOUNTS"); linkExistingAccounts != "" {
+			var err error
+			config.LinkExistingAccounts, err = strconv.ParseBool(linkExistingAccounts)
+			if err != nil {
+				return nil, fmt.Errorf("oidc provider %s: invalid %sLINK_EXISTING_ACCOUNTS %q", name, envPrefix, linkExistingAccounts)
+			}
+		}
+
+		providers[name] = NewProvider(name, config)
+	}
+	return providers, nil
+}
+
+func (p *oidcProvider) Name() string {
+	return p.name
+}
+
+func (p *oidcProvider) LinkExistingAccounts() bool {
+	return p.config.LinkExistingAccounts
+}
+
+// discover reads the discovery document the first time it is needed, a failed attempt is retried on the next call.
+func (p *oidcProvider) discover(ctx context.Context) (*oauth2.Config, *gooidc.IDTokenVerifier, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.oauth2Config != nil {
+		return p.oauth2Config, p.verifier, nil
+	}
+
+	provider, err := gooidc.NewProvider(ctx, p.config.IssuerUrl)
+	if err != nil {
+		return nil, nil, fmt.Errorf("oidc provider %s discovery: %w", p.name, err)
+	}
+
+	verifierConfig := &gooidc.Config{ClientID: p.config.ClientId}
+	verifier := provider.Verifier(verifierConfig)
+	if p.config.JwksUrl != "" {
+		// the key set outlives the request that happened to trigger the discovery
+		verifier = gooidc.NewVerifier(p.config.IssuerUrl, gooidc.NewRemoteKeySet(context.WithoutCancel(ctx), p.config.JwksUrl), verifierConfig)
+	}
+
+	p.oauth2Config = &oauth2.Config{
+		ClientID:     p.config.ClientId,
+		ClientSecret: p.config.ClientSecret,
+		RedirectURL:  p.config.RedirectUrl,
+		Endpoint:     provider.Endpoint(),
+		Scopes:       []string{gooidc.ScopeOpenID, "email", "profile"},
+	}
+	p.verifier = verifier
+	return p.oauth2Config, p.verifier, nil
+}
+
+func (p *oidcProvider) AuthCodeUrl(ctx context.Context, state string, nonce string, codeVerifier string) (string, error) {
+	oauth2Config, _, err := p.discover(ctx)
+	if err != nil {
+		return "", err
+	}
+	return oauth2Config.AuthCodeURL(state, gooidc.Nonce(nonce), oauth2.S256ChallengeOption(codeVerifier)), nil
+}
+
+func (p *oidcProvider) Exchange(ctx context.Context, code string, nonce string, codeVerifier string) (*Identity, error) {
+	oauth2Config, verifier, err := p.discover(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := oauth2Config.Exchange(ctx, code, oauth2.VerifierOption(codeVerifier))
+	if err != nil {
+		return nil, err
+	}
+
+	rawIdToken, ok := token.Extra("id_token").(string)
+	if !ok || rawIdToken == "" {
+		return nil, ErrMissingIdToken
+	}
+
+	idToken, err := verifier.Verify(ctx, rawIdToken)
+	if err != nil {
+		return nil, err
+	}
+	if idToken.Nonce != nonce {
+		return nil, errors.New("id token nonce does not match")
+	}
+
+	var claims struct {
+		Email         string `json:"email"`
+		EmailVerified bool   `json:"email_verified"`
+		Name          string `json:"name"`
+	}
+	err = idToken.Claims(&claims)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Identity{
+		Subject:       idToken.Subject,
+		Email:         strings.TrimSpace(claims.Email),
+		EmailVerified: claims.EmailVerified,
+		Name:          claims.Name,
+	}, nil
+}
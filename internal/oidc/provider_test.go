@@ -0,0 +1,183 @@
+package oidc
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/adaptor"
+)
+
+// newTestIssuer serves a DevIssuer that answers 503 until available is set.
+func newTestIssuer(t *testing.T) (*httptest.Server, *atomic.Bool) {
+	t.Helper()
+
+	// the issuer url is only known once the server listens, so the app is plugged in afterwards
+	var handler http.Handler
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		handler.ServeHTTP(w, r)
+	}))
+	t.Cleanup(server.Close)
+
+	devIssuer, err := NewDevIssuer(server.URL)
+	if err != nil {
+		t.Fatalf("NewDevIssuer: %v", err)
+	}
+
+	available := &atomic.Bool{}
+	app := fiber.New()
+	app.Use(func(c *fiber.Ctx) error {
+		if !available.Load() {
+			return c.SendStatus(http.StatusServiceUnavailable)
+		}
+		return c.Next()
+	})
+	devIssuer.Register(app)
+	handler = adaptor.FiberApp(app)
+	return server, available
+}
+
+func newTestProvider(issuerUrl string) Provider {
+	return NewProvider("dev", ProviderConfig{
+		IssuerUrl:    issuerUrl,
+		ClientId:     "test-client",
+		ClientSecret: "test-secret",
+		RedirectUrl:  "http://localhost/callback",
+	})
+}
+
+// authorize signs the email in at the issuer and returns the code of the redirect back to the client.
+func authorize(t *testing.T, authorizationUrl string, email string) string {
+	t.Helper()
+
+	authorizeUrl, err := url.Parse(authorizationUrl)
+	if err != nil {
+		t.Fatalf("parse authorization url: %v", err)
+	}
+	query := authorizeUrl.Query()
+	query.Set("login_hint", email)
+	authorizeUrl.RawQuery = query.Encode()
+
+	client := &http.Client{
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+	resp, err := client.Get(authorizeUrl.String())
+	if err != nil {
+		t.Fatalf("authorize: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusFound {
+		t.Fatalf("authorize status = %d, want %d", resp.StatusCode, http.StatusFound)
+	}
+
+	callbackUrl, err := url.Parse(resp.Header.Get("Location"))
+	if err != nil {
+		t.Fatalf("parse callback url: %v", err)
+	}
+	if callbackUrl.Query().Get("state") != "state" {
+		t.Fatalf("callback state = %q, want %q", callbackUrl.Query().Get("state"), "state")
+	}
+	return callbackUrl.Query().Get("code")
+}
+
+func TestProviderLogin(t *testing.T) {
+	server, available := newTestIssuer(t)
+	available.Store(true)
+	provider := newTestProvider(server.URL)
+	ctx := context.Background()
+
+	codeVerifier := "0123456789abcdefghijklmnopqrstuvwxyzABCDEFG"
+	authorizationUrl, err := provider.AuthCodeUrl(ctx, "state", "nonce", codeVerifier)
+	if err != nil {
+		t.Fatalf("AuthCodeUrl: %v", err)
+	}
+
+	code := authorize(t, authorizationUrl, "jane@example.com")
+	identity, err := provider.Exchange(ctx, code, "nonce", codeVerifier)
+	if err != nil {
+		t.Fatalf("Exchange: %v", err)
+	}
+	if identity.Email != "jane@example.com" || !identity.EmailVerified || identity.Subject == "" {
+		t.Fatalf("identity = %+v", identity)
+	}
+
+	// a code is redeemed once
+	_, err = provider.Exchange(ctx, code, "nonce", codeVerifier)
+	if err == nil {
+		t.Fatal("Exchange of a spent code succeeded")
+	}
+}
+
+func TestProviderRejectsWrongNonce(t *testing.T) {
+	server, available := newTestIssuer(t)
+	available.Store(true)
+	provider := newTestProvider(server.URL)
+	ctx := context.Background()
+
+	codeVerifier := "0123456789abcdefghijklmnopqrstuvwxyzABCDEFG"
+	authorizationUrl, err := provider.AuthCodeUrl(ctx, "state", "nonce", codeVerifier)
+	if err != nil {
+		t.Fatalf("AuthCodeUrl: %v", err)
+	}
+
+	code := authorize(t, authorizationUrl, "jane@example.com")
+	_, err = provider.Exchange(ctx, code, "other-nonce", codeVerifier)
+	if err == nil {
+		t.Fatal("Exchange with a different nonce succeeded")
+	}
+}
+
+func TestProviderRetriesDiscovery(t *testing.T) {
+	server, available := newTestIssuer(t)
+	provider := newTestProvider(server.URL)
+	ctx := context.Background()
+
+	_, err := provider.AuthCodeUrl(ctx, "state", "nonce", "verifier")
+	if err == nil {
+		t.Fatal("AuthCodeUrl succeeded while the issuer was down")
+	}
+
+	available.Store(true)
+	_, err = provider.AuthCodeUrl(ctx, "state", "nonce", "verifier")
+	if err != nil {
+		t.Fatalf("AuthCodeUrl after the issuer came back: %v", err)
+	}
+}
+
+func TestNewProvidersFromEnv(t *testing.T) {
+	t.Setenv("OIDC_PROVIDERS", "dev, other")
+	t.Setenv("OIDC_DEV_ISSUER_URL", "http://127.0.0.1:1")
+	t.Setenv("OIDC_DEV_CLIENT_ID", "client")
+	t.Setenv("OIDC_DEV_REDIRECT_URL", "http://localhost/callback")
+	t.Setenv("OIDC_DEV_LINK_EXISTING_ACCOUNTS", "true")
+	t.Setenv("OIDC_OTHER_ISSUER_URL", "http://127.0.0.1:1")
+	t.Setenv("OIDC_OTHER_CLIENT_ID", "client")
+	t.Setenv("OIDC_OTHER_REDIRECT_URL", "http://localhost/callback")
+
+	// the issuers are unreachable, which must not fail the startup
+	providers, err := NewProvidersFromEnv()
+	if err != nil {
+		t.Fatalf("NewProvidersFromEnv: %v", err)
+	}
+	if len(providers) != 2 {
+		t.Fatalf("providers = %d, want 2", len(providers))
+	}
+	if !providers["dev"].LinkExistingAccounts() {
+		t.Error("dev provider does not link existing accounts")
+	}
+	if providers["other"].LinkExistingAccounts() {
+		t.Error("other provider links existing accounts without opting in")
+	}
+
+	t.Setenv("OIDC_OTHER_LINK_EXISTING_ACCOUNTS", "sometimes")
+	_, err = NewProvidersFromEnv()
+	if err == nil {
+		t.Fatal("NewProvidersFromEnv accepted an invalid OIDC_OTHER_LINK_EXISTING_ACCOUNTS")
+	}
+}
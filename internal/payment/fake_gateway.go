@@ -0,0 +1,143 @@
+package payment
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// fakeGateway keeps invoices in memory and never calls the network.
+// It is meant for local development only: callbacks are not authenticated, so it is refused outside ENVIRONMENT=DEV.
+type fakeGateway struct {
+	invoiceBaseUrl string
+
+	mu       sync.Mutex
+	invoices map[string]*Invoice
+	refunds  map[string]*Refund
+}
+
+type fakeCallbackRequest struct {
+	InvoiceId      string     `json:"invoice_id"`
+	OrderId        string     `json:"order_id"`
+	Status         string     `json:"status"`
+	Amount         float64    `json:"amount"`
+	PaidAt         *time.Time `json:"paid_at"`
+	PaymentMethod  string     `json:"payment_method"`
+	PaymentChannel string     `json:"payment_channel"`
+}
+
+func NewFakeGateway(invoiceBaseUrl string) *fakeGateway {
+	return &fakeGateway{
+		invoiceBaseUrl: invoiceBaseUrl,
+		invoices:       make(map[string]*Invoice),
+		refunds:        make(map[string]*Refund),
+	}
+}
+
+func (fg *fakeGateway) Provider() string {
+	return ProviderFake
+}
+
+func (fg *fakeGateway) CreateInvoice(ctx context.Context, params *CreateInvoiceParams) (*Invoice, error) {
+	fg.mu.Lock()
+	defer fg.mu.Unlock()
+
+	invoiceId := uuid.NewString()
+	fakeInvoice := &Invoice{
+		Id:     invoiceId,
+		Url:    fmt.Sprintf("%s/fake-invoice/%s", fg.invoiceBaseUrl, invoiceId),
+		Status: InvoiceStatusPending,
+		Amount: params.Amount,
+	}
+	fg.invoices[invoiceId] = fakeInvoice
+
+	invoiceCopy := *fakeInvoice
+	return &invoiceCopy, nil
+}
+
+func (fg *fakeGateway) GetInvoice(ctx context.Context, invoiceId string) (*Invoice, error) {
+	fg.mu.Lock()
+	defer fg.mu.Unlock()
+
+	fakeInvoice, ok := fg.invoices[invoiceId]
+	if !ok {
+		return nil, fmt.Errorf("invoice %s not found", invoiceId)
+	}
+
+	invoiceCopy := *fakeInvoice
+	return &invoiceCopy, nil
+}
+
+func (fg *fakeGateway) ExpireInvoice(ctx context.Context, invoiceId string) (*Invoice, error) {
+	fg.mu.Lock()
+	defer fg.mu.Unlock()
+
+	fakeInvoice, ok := fg.invoices[invoiceId]
+	if !ok {
+		// invoices created by another process are unknown here, treat them as expired
+		return &Invoice{Id: invoiceId, Status: InvoiceStatusExpired}, nil
+	}
+	if fakeInvoice.Status != InvoiceStatusPending {
+		return nil, errors.New("invoice can not be expired")
+	}
+	fakeInvoice.Status = InvoiceStatusExpired
+
+	invoiceCopy := *fakeInvoice
+	return &invoiceCopy, nil
+}
+
+func (fg *fakeGateway) RefundInvoice(ctx context.Context, params *RefundParams) (*Refund, error) {
+	fg.mu.Lock()
+	defer fg.mu.Unlock()
+
+	fakeRefund, ok := fg.refunds[params.RefundId]
+	if ok {
+		refundCopy := *fakeRefund
+		return &refundCopy, nil
+	}
+
+	// payments of the fake provider are confirmed by the webhook in the REST process,
+	// so the invoice kept here is not required to be paid
+	fakeInvoice, ok := fg.invoices[params.InvoiceId]
+	if ok && params.Amount > fakeInvoice.Amount {
+		return nil, fmt.Errorf("%w: refund amount is greater than the invoice amount", ErrRefundRejected)
+	}
+
+	fakeRefund = &Refund{
+		Id:     uuid.NewString(),
+		Status: "SUCCEEDED",
+		Amount: params.Amount,
+	}
+	fg.refunds[params.RefundId] = fakeRefund
+
+	refundCopy := *fakeRefund
+	return &refundCopy, nil
+}
+
+func (fg *fakeGateway) ParseCallback(header http.Header, body []byte) (*CallbackEvent, error) {
+	var request fakeCallbackRequest
+	err := json.Unmarshal(body, &request)
+	if err != nil {
+		return nil, err
+	}
+	if request.InvoiceId == "" || request.OrderId == "" {
+		return nil, ErrInvalidCallback
+	}
+
+	return &CallbackEvent{
+		InvoiceId:      request.InvoiceId,
+		OrderId:        request.OrderId,
+		Status:         request.Status,
+		Amount:         request.Amount,
+		PaidAt:         request.PaidAt,
+		PaymentMethod:  request.PaymentMethod,
+		PaymentChannel: request.PaymentChannel,
+		Payload:        string(body),
+	}, nil
+}
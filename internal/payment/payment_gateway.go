@@ -0,0 +1,116 @@
+package payment
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"os"
+	"time"
+)
+
+const (
+	ProviderXendit = "xendit"
+	ProviderFake   = "fake"
+)
+
+const (
+	InvoiceStatusPending = "PENDING"
+	InvoiceStatusPaid    = "PAID"
+	InvoiceStatusSettled = "SETTLED"
+	InvoiceStatusExpired = "EXPIRED"
+)
+
+var ErrInvalidCallback = errors.New("invalid payment callback")
+
+// ErrRefundRejected is returned when the provider refused the refund, so it can never succeed with the same request.
+// Other refund errors may hide a refund that went through and must be retried with the same RefundId.
+var ErrRefundRejected = errors.New("refund rejected by the payment provider")
+
+// AmountsEqual compares two amounts in minor units (hundredths), so the same amount parsed from JSON or summed from
+// item prices still matches despite float rounding.
+func AmountsEqual(a float64, b float64) bool {
+	return math.Round(a*100) == math.Round(b*100)
+}
+
+type InvoiceItem struct {
+	Name     string
+	Price    float64
+	Quantity int64
+}
+
+type CreateInvoiceParams struct {
+	OrderId            string
+	Amount             float64
+	Currency           string
+	CustomerName       string
+	SuccessRedirectUrl string
+	ExpiredAt          time.Time
+	Items              []InvoiceItem
+}
+
+type Invoice struct {
+	Id             string
+	Url            string
+	Status         string
+	Amount         float64
+	PaidAt         *time.Time
+	PaymentMethod  string
+	PaymentChannel string
+}
+
+type RefundParams struct {
+	// RefundId is our own refund id, sent as the reference so a retried request is not refunded twice
+	RefundId  string
+	InvoiceId string
+	Amount    float64
+	Reason    string
+}
+
+type Refund struct {
+	Id     string
+	Status string
+	Amount float64
+}
+
+// CallbackEvent is an invoice status notification sent by the provider, already verified.
+type CallbackEvent struct {
+	InvoiceId      string
+	OrderId        string
+	Status         string
+	Amount         float64
+	PaidAt         *time.Time
+	PaymentMethod  string
+	PaymentChannel string
+	Payload        string
+}
+
+// PaymentGateway hides the payment provider behind the operations the order flow needs.
+type PaymentGateway interface {
+	Provider() string
+	CreateInvoice(ctx context.Context, params *CreateInvoiceParams) (*Invoice, error)
+	GetInvoice(ctx context.Context, invoiceId string) (*Invoice, error)
+	ExpireInvoice(ctx context.Context, invoiceId string) (*Invoice, error)
+	// RefundInvoice returns (part of) the amount paid on an invoice to the customer.
+	// Calls with the same RefundId refund only once. It returns ErrRefundRejected when the provider refused the refund.
+	RefundInvoice(ctx context.Context, params *RefundParams) (*Refund, error)
+	// ParseCallback verifies the callback came from the provider and decodes it.
+	// It returns ErrInvalidCallback when the request can not be trusted.
+	ParseCallback(header http.Header, body []byte) (*CallbackEvent, error)
+}
+
+// NewPaymentGatewayFromEnv builds the gateway selected by PAYMENT_GATEWAY, Xendit when it is not set.
+func NewPaymentGatewayFromEnv() (PaymentGateway, error) {
+	switch os.Getenv("PAYMENT_GATEWAY") {
+	case "", ProviderXendit:
+		return NewXenditGateway(os.Getenv("XENDIT_SECRET_KEY"), os.Getenv("XENDIT_CALLBACK_TOKEN")), nil
+	case ProviderFake:
+		// anyone can post a fake callback and mark orders paid, so its webhook route must never be reachable in production
+		if os.Getenv("ENVIRONMENT") != "DEV" {
+			return nil, fmt.Errorf("payment gateway %q is only allowed with ENVIRONMENT=DEV", ProviderFake)
+		}
+		return NewFakeGateway(os.Getenv("FE_BASE_URL")), nil
+	}
+	return nil, fmt.Errorf("unknown payment gateway %q", os.Getenv("PAYMENT_GATEWAY"))
+}
@@ -0,0 +1,41 @@
+package payment
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/arthurhzna/Golang_gRPC/internal/logging"
+	"github.com/xendit/xendit-go"
+)
+
+// loggingApiRequester sends the request id of the incoming call to Xendit in X-Request-Id
+// and logs every outgoing call, so a payment problem can be followed from our logs to Xendit's.
+type loggingApiRequester struct {
+	apiRequester xendit.APIRequester
+}
+
+func (lar *loggingApiRequester) Call(ctx context.Context, method string, url string, secretKey string, header http.Header, body interface{}, result interface{}) *xendit.Error {
+	if header == nil {
+		header = http.Header{}
+	}
+	if requestId := logging.RequestIdFromContext(ctx); requestId != "" {
+		header.Set(logging.RequestIdHeader, requestId)
+	}
+
+	start := time.Now()
+	xenditErr := lar.apiRequester.Call(ctx, method, url, secretKey, header, body, result)
+	attrs := []slog.Attr{
+		slog.String("http_method", method),
+		slog.String("url", url),
+		slog.Int64("duration_ms", time.Since(start).Milliseconds()),
+	}
+	if xenditErr != nil {
+		attrs = append(attrs, slog.Int("status", xenditErr.Status), slog.String("error_code", xenditErr.ErrorCode), slog.String("error", xenditErr.Message))
+		slog.LogAttrs(ctx, slog.LevelWarn, "xendit request failed", attrs...)
+		return xenditErr
+	}
+	slog.LogAttrs(ctx, slog.LevelDebug, "xendit request", attrs...)
+	return nil
+}
@@ -0,0 +1,59 @@
+package pubsub
+
+import (
+	"context"
+	"sync"
+)
+
+// subscriberBufferSize is how many events a slow subscriber can fall behind before events to it are dropped.
+const subscriberBufferSize = 16
+
+type memoryOrderEventBus struct {
+	mu          sync.Mutex
+	subscribers map[string]map[chan *OrderStatusEvent]struct{}
+}
+
+func NewMemoryOrderEventBus() *memoryOrderEventBus {
+	return &memoryOrderEventBus{
+		subscribers: make(map[string]map[chan *OrderStatusEvent]struct{}),
+	}
+}
+
+func (mb *memoryOrderEventBus) Publish(ctx context.Context, event *OrderStatusEvent) error {
+	mb.mu.Lock()
+	defer mb.mu.Unlock()
+
+	for subscriber := range mb.subscribers[event.OrderId] {
+		select {
+		case subscriber <- event:
+		default:
+		}
+	}
+	return nil
+}
+
+func (mb *memoryOrderEventBus) Subscribe(orderId string) (<-chan *OrderStatusEvent, func()) {
+	mb.mu.Lock()
+	defer mb.mu.Unlock()
+
+	subscriber := make(chan *OrderStatusEvent, subscriberBufferSize)
+	if mb.subscribers[orderId] == nil {
+		mb.subscribers[orderId] = make(map[chan *OrderStatusEvent]struct{})
+	}
+	mb.subscribers[orderId][subscriber] = struct{}{}
+
+	var once sync.Once
+	unsubscribe := func() {
+		once.Do(func() {
+			mb.mu.Lock()
+			defer mb.mu.Unlock()
+
+			delete(mb.subscribers[orderId], subscriber)
+			if len(mb.subscribers[orderId]) == 0 {
+				delete(mb.subscribers, orderId)
+			}
+			close(subscriber)
+		})
+	}
+	return subscriber, unsubscribe
+}
@@ -0,0 +1,42 @@
+package pubsub
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"os"
+	"time"
+)
+
+const (
+	OrderEventBusMemory   = "memory"
+	OrderEventBusPostgres = "postgres"
+)
+
+type OrderStatusEvent struct {
+	OrderId         string    `json:"order_id"`
+	UserId          string    `json:"user_id"`
+	OrderStatusCode string    `json:"order_status_code"`
+	ChangedAt       time.Time `json:"changed_at"`
+}
+
+// OrderEventBus carries order status changes to the WatchOrder streams.
+type OrderEventBus interface {
+	// Publish must be called after the status change is committed.
+	Publish(ctx context.Context, event *OrderStatusEvent) error
+	// Subscribe returns the events of one order until unsubscribe is called.
+	Subscribe(orderId string) (events <-chan *OrderStatusEvent, unsubscribe func())
+}
+
+// NewOrderEventBusFromEnv builds the bus selected by ORDER_EVENT_BUS, in-process when it is not set.
+// The in-process bus only reaches streams of the same process, use postgres when the REST webhook
+// server and the gRPC server run separately.
+func NewOrderEventBusFromEnv(ctx context.Context, db *sql.DB) (OrderEventBus, error) {
+	switch os.Getenv("ORDER_EVENT_BUS") {
+	case "", OrderEventBusMemory:
+		return NewMemoryOrderEventBus(), nil
+	case OrderEventBusPostgres:
+		return NewPostgresOrderEventBus(ctx, db, os.Getenv("DB_URL"))
+	}
+	return nil, fmt.Errorf("unknown order event bus %q", os.Getenv("ORDER_EVENT_BUS"))
+}
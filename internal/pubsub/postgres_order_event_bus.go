@@ -0,0 +1,81 @@
+package pubsub
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"log/slog"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+const orderEventChannel = "order_status_changed"
+
+// postgresOrderEventBus sends events through Postgres LISTEN/NOTIFY, so a change made by one process
+// reaches the streams of every process. Events received from Postgres are fanned out in-process.
+type postgresOrderEventBus struct {
+	db       *sql.DB
+	listener *pq.Listener
+	local    *memoryOrderEventBus
+}
+
+func NewPostgresOrderEventBus(ctx context.Context, db *sql.DB, dbUrl string) (OrderEventBus, error) {
+	listener := pq.NewListener(dbUrl, 10*time.Second, time.Minute, func(event pq.ListenerEventType, err error) {
+		if err != nil {
+			slog.Error("Order event listener failed", "error", err)
+		}
+	})
+	err := listener.Listen(orderEventChannel)
+	if err != nil {
+		listener.Close()
+		return nil, err
+	}
+
+	bus := &postgresOrderEventBus{
+		db:       db,
+		listener: listener,
+		local:    NewMemoryOrderEventBus(),
+	}
+	go bus.listen(ctx)
+	return bus, nil
+}
+
+func (pb *postgresOrderEventBus) Publish(ctx context.Context, event *OrderStatusEvent) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	// the notification comes back to this process as well, it is delivered to local streams by listen
+	_, err = pb.db.ExecContext(ctx, "SELECT pg_notify($1, $2)", orderEventChannel, string(payload))
+	return err
+}
+
+func (pb *postgresOrderEventBus) Subscribe(orderId string) (<-chan *OrderStatusEvent, func()) {
+	return pb.local.Subscribe(orderId)
+}
+
+func (pb *postgresOrderEventBus) listen(ctx context.Context) {
+	defer pb.listener.Close()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case notification := <-pb.listener.Notify:
+			// nil after a reconnect, notifications sent while disconnected are lost
+			if notification == nil {
+				continue
+			}
+			var event OrderStatusEvent
+			err := json.Unmarshal([]byte(notification.Extra), &event)
+			if err != nil {
+				slog.Error("Order event listener received an invalid payload", "payload", notification.Extra, "error", err)
+				continue
+			}
+			pb.local.Publish(ctx, &event)
+		case <-time.After(90 * time.Second):
+			go pb.listener.Ping()
+		}
+	}
+}
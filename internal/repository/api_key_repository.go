@@ -0,0 +1,146 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/lib/pq"
+
+	"github.com/arthurhzna/Golang_gRPC/internal/entity"
+	"github.com/arthurhzna/Golang_gRPC/pkg/database"
+)
+
+type IApiKeyRepository interface {
+	CreateApiKey(ctx context.Context, apiKey *entity.ApiKey) error
+	GetApiKeyById(ctx context.Context, id string) (*entity.ApiKey, error)
+	GetApiKeyByHash(ctx context.Context, keyHash string) (*entity.ApiKey, error)
+	GetApiKeysByServiceAccountId(ctx context.Context, serviceAccountId string) ([]*entity.ApiKey, error)
+	RevokeApiKey(ctx context.Context, id string, revokedAt time.Time) error
+	UpdateApiKeyLastUsed(ctx context.Context, id string, lastUsedAt time.Time) error
+}
+
+type apiKeyRepository struct {
+	db database.DatabaseQuery
+}
+
+func NewApiKeyRepository(db database.DatabaseQuery) IApiKeyRepository {
+	return &apiKeyRepository{db: db}
+}
+
+const apiKeyColumns = `id, service_account_id, name, prefix, key_hash, scopes, created_at, created_by, expires_at, last_used_at, revoked_at`
+
+func scanApiKey(scanner interface{ Scan(dest ...any) error }) (*entity.ApiKey, error) {
+	var apiKey entity.ApiKey
+	err := scanner.Scan(
+		&apiKey.Id,
+		&apiKey.ServiceAccountId,
+		&apiKey.Name,
+		&apiKey.Prefix,
+		&apiKey.KeyHash,
+		pq.Array(&apiKey.Scopes),
+		&apiKey.CreatedAt,
+		&apiKey.CreatedBy,
+		&apiKey.ExpiresAt,
+		&apiKey.LastUsedAt,
+		&apiKey.RevokedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &apiKey, nil
+}
+
+func (ar *apiKeyRepository) CreateApiKey(ctx context.Context, apiKey *entity.ApiKey) error {
+	_, err := ar.db.ExecContext(
+		ctx,
+		`INSERT INTO "api_key" (id, service_account_id, name, prefix, key_hash, scopes, created_at, created_by, expires_at) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`,
+		apiKey.Id,
+		apiKey.ServiceAccountId,
+		apiKey.Name,
+		apiKey.Prefix,
+		apiKey.KeyHash,
+		pq.Array(apiKey.Scopes),
+		apiKey.CreatedAt,
+		apiKey.CreatedBy,
+		apiKey.ExpiresAt,
+	)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (ar *apiKeyRepository) GetApiKeyById(ctx context.Context, id string) (*entity.ApiKey, error) {
+	apiKey, err := scanApiKey(ar.db.QueryRowContext(ctx, `SELECT `+apiKeyColumns+` FROM "api_key" WHERE id = $1`, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return apiKey, nil
+}
+
+func (ar *apiKeyRepository) GetApiKeyByHash(ctx context.Context, keyHash string) (*entity.ApiKey, error) {
+	apiKey, err := scanApiKey(ar.db.QueryRowContext(ctx, `SELECT `+apiKeyColumns+` FROM "api_key" WHERE key_hash = $1`, keyHash))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return apiKey, nil
+}
+
+func (ar *apiKeyRepository) GetApiKeysByServiceAccountId(ctx context.Context, serviceAccountId string) ([]*entity.ApiKey, error) {
+	rows, err := ar.db.QueryContext(
+		ctx,
+		`SELECT `+apiKeyColumns+` FROM "api_key" WHERE service_account_id = $1 ORDER BY created_at`,
+		serviceAccountId,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var apiKeys []*entity.ApiKey = make([]*entity.ApiKey, 0)
+	for rows.Next() {
+		apiKey, err := scanApiKey(rows)
+		if err != nil {
+			return nil, err
+		}
+		apiKeys = append(apiKeys, apiKey)
+	}
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+	return apiKeys, nil
+}
+
+func (ar *apiKeyRepository) RevokeApiKey(ctx context.Context, id string, revokedAt time.Time) error {
+	_, err := ar.db.ExecContext(
+		ctx,
+		`UPDATE "api_key" SET revoked_at = $1 WHERE id = $2 AND revoked_at IS NULL`,
+		revokedAt,
+		id,
+	)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (ar *apiKeyRepository) UpdateApiKeyLastUsed(ctx context.Context, id string, lastUsedAt time.Time) error {
+	_, err := ar.db.ExecContext(
+		ctx,
+		`UPDATE "api_key" SET last_used_at = $1 WHERE id = $2`,
+		lastUsedAt,
+		id,
+	)
+	if err != nil {
+		return err
+	}
+	return nil
+}
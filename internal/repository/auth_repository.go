@@ -1,131 +1,309 @@
-package repository
-
-import (
-	"context"
-	"database/sql"
-	"errors"
-	"time"
-
-	"github.com/arthurhzna/Golang_gRPC/internal/entity"
-)
-
-type IAuthRepository interface {
-	GetUserByEmail(ctx context.Context, email string) (*entity.User, error)
-	InsertUser(ctx context.Context, user *entity.User) error
-	UpdateUserPassword(ctx context.Context, userId string, hashNewPassword string, updatedBy string) error
-}
-
-type authRepository struct {
-	db *sql.DB
-}
-
-func NewAuthRepository(db *sql.DB) IAuthRepository {
-	return &authRepository{db: db}
-}
-
-func (ar *authRepository) GetUserByEmail(ctx context.Context, email string) (*entity.User, error) {
-
-	// row := ar.db.QueryRowContext(ctx,
-	// 	"SELECT id, email, password, full_name FROM user WHERE email = $1 AND is_deleted IS false",
-	// 	email)
-
-	row := ar.db.QueryRowContext(ctx,
-		`SELECT id, email, password, full_name, role_code, created_at
-		 FROM "user" 
-		 WHERE email = $1 AND is_deleted IS false`,
-		email)
-
-	if row.Err() != nil {
-		return nil, row.Err()
-	}
-
-	var user entity.User
-	err := row.Scan(
-		&user.Id,
-		&user.Email,
-		&user.Password,
-		&user.FullName,
-		&user.RoleCode,
-		&user.CreatedAt,
-	)
-	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			return nil, nil
-		}
-		return nil, err
-	}
-	return &user, nil
-}
-
-// func (ar *authRepository) InsertUser(ctx context.Context, user *entity.User) error {
-
-// 	_, err := ar.db.ExecContext(
-// 		ctx,
-// 		"INSERT INTO user (id, full_name, email, password, role_code, created_at, created_by, update_at, updated_by, deleted_at, deleted_by, is_deleted) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)",
-// 		user.Id,
-// 		user.FullName,
-// 		user.Email,
-// 		user.Password,
-// 		user.RoleCode,
-// 		user.CreatedAt,
-// 		user.CreatedBy,
-// 		user.UpdateAt,
-// 		user.UpdatedBy,
-// 		user.DeletedAt,
-// 		user.DeletedBy,
-// 		user.IsDelated,
-// 	)
-
-// 	if err != nil {
-// 		return err
-// 	}
-
-// 	return nil
-// }
-
-func (ar *authRepository) InsertUser(ctx context.Context, user *entity.User) error {
-
-	_, err := ar.db.ExecContext(
-		ctx,
-		`INSERT INTO "user"
-		(id, full_name, email, password, role_code,
-		 created_at, created_by, updated_at, updated_by,
-		 deleted_at, deleted_by, is_deleted)
-		VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12)`,
-		user.Id,
-		user.FullName,
-		user.Email,
-		user.Password,
-		user.RoleCode,
-		user.CreatedAt,
-		user.CreatedBy,
-		user.UpdatedAt,
-		user.UpdatedBy,
-		user.DeletedAt,
-		user.DeletedBy,
-		user.IsDeleted, // ini harus sesuai struct entity
-	)
-
-	if err != nil {
-		return err
-	}
-
-	return nil
-}
-
-func (ar *authRepository) UpdateUserPassword(ctx context.Context, userId string, hashNewPassword string, updatedBy string) error {
-
-	_, err := ar.db.ExecContext(
-		ctx,
-		`UPDATE "user" SET password = $1, updated_at = $2, updated_by = $3 WHERE id = $4`,
-		hashNewPassword,
-		time.Now(),
-		updatedBy,
-		userId,
-	)
-	if err != nil {
-		return err
-	}
-
-	return nil
-}
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/arthurhzna/Golang_gRPC/internal/entity"
+	"github.com/arthurhzna/Golang_gRPC/pkg/database"
+)
+
+type IAuthRepository interface {
+	WithTransaction(tx *sql.Tx) IAuthRepository
+	GetUserById(ctx context.Context, id string) (*entity.User, error)
+	GetUserByEmail(ctx context.Context, email string) (*entity.User, error)
+	IsEmailRegistered(ctx context.Context, email string) (bool, error)
+	InsertUser(ctx context.Context, user *entity.User) error
+	UpdateUserPassword(ctx context.Context, userId string, hashNewPassword string, updatedBy string) error
+	UpdateUserEmailVerified(ctx context.Context, userId string, verifiedAt time.Time, updatedBy string) error
+	UpdateUserProfile(ctx context.Context, userId string, fullName string, phoneNumber *string, address *string, updatedBy string) error
+	// UpdateUserEmail sets a confirmed new email, confirming it also verifies it
+	UpdateUserEmail(ctx context.Context, userId string, email string, verifiedAt time.Time, updatedBy string) error
+	// UpdateUserTotpSecret stores a secret waiting for confirmation, or turns two-factor authentication off when secret is nil
+	UpdateUserTotpSecret(ctx context.Context, userId string, secret *string, updatedBy string) error
+	EnableUserTotp(ctx context.Context, userId string, enabledAt time.Time, updatedBy string) error
+	// UseUserTotpStep records the time step of an accepted code, it returns false when that step or a later one was already used
+	UseUserTotpStep(ctx context.Context, userId string, step int64) (bool, error)
+}
+
+type authRepository struct {
+	db database.DatabaseQuery
+}
+
+func NewAuthRepository(db database.DatabaseQuery) IAuthRepository {
+	return &authRepository{db: db}
+}
+
+func (ar *authRepository) WithTransaction(tx *sql.Tx) IAuthRepository {
+	return &authRepository{db: tx}
+}
+
+func (ar *authRepository) GetUserById(ctx context.Context, id string) (*entity.User, error) {
+
+	row := ar.db.QueryRowContext(ctx,
+		`SELECT id, email, password, full_name, role_code, created_at, email_verified_at, phone_number, address, totp_secret, totp_enabled_at, totp_last_used_step
+		 FROM "user"
+		 WHERE id = $1 AND is_deleted IS false`,
+		id)
+
+	if row.Err() != nil {
+		return nil, row.Err()
+	}
+
+	var user entity.User
+	err := row.Scan(
+		&user.Id,
+		&user.Email,
+		&user.Password,
+		&user.FullName,
+		&user.RoleCode,
+		&user.CreatedAt,
+		&user.EmailVerifiedAt,
+		&user.PhoneNumber,
+		&user.Address,
+		&user.TotpSecret,
+		&user.TotpEnabledAt,
+		&user.TotpLastUsedStep,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &user, nil
+}
+
+func (ar *authRepository) GetUserByEmail(ctx context.Context, email string) (*entity.User, error) {
+
+	// row := ar.db.QueryRowContext(ctx,
+	// 	"SELECT id, email, password, full_name FROM user WHERE email = $1 AND is_deleted IS false",
+	// 	email)
+
+	row := ar.db.QueryRowContext(ctx,
+		`SELECT id, email, password, full_name, role_code, created_at, email_verified_at, phone_number, address, totp_secret, totp_enabled_at, totp_last_used_step
+		 FROM "user" 
+		 WHERE email = $1 AND is_deleted IS false`,
+		email)
+
+	if row.Err() != nil {
+		return nil, row.Err()
+	}
+
+	var user entity.User
+	err := row.Scan(
+		&user.Id,
+		&user.Email,
+		&user.Password,
+		&user.FullName,
+		&user.RoleCode,
+		&user.CreatedAt,
+		&user.EmailVerifiedAt,
+		&user.PhoneNumber,
+		&user.Address,
+		&user.TotpSecret,
+		&user.TotpEnabledAt,
+		&user.TotpLastUsedStep,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &user, nil
+}
+
+func (ar *authRepository) IsEmailRegistered(ctx context.Context, email string) (bool, error) {
+
+	var registered bool
+	err := ar.db.QueryRowContext(ctx,
+		`SELECT EXISTS (SELECT 1 FROM "user" WHERE email = $1)`,
+		email).Scan(&registered)
+	if err != nil {
+		return false, err
+	}
+	return registered, nil
+}
+
+// func (ar *authRepository) InsertUser(ctx context.Context, user *entity.User) error {
+
+// 	_, err := ar.db.ExecContext(
+// 		ctx,
+// 		"INSERT INTO user (id, full_name, email, password, role_code, created_at, created_by, update_at, updated_by, deleted_at, deleted_by, is_deleted) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)",
+// 		user.Id,
+// 		user.FullName,
+// 		user.Email,
+// 		user.Password,
+// 		user.RoleCode,
+// 		user.CreatedAt,
+// 		user.CreatedBy,
+// 		user.UpdateAt,
+// 		user.UpdatedBy,
+// 		user.DeletedAt,
+// 		user.DeletedBy,
+// 		user.IsDelated,
+// 	)
+
+// 	if err != nil {
+// 		return err
+// 	}
+
+// 	return nil
+// }
+
+func (ar *authRepository) InsertUser(ctx context.Context, user *entity.User) error {
+
+	_, err := ar.db.ExecContext(
+		ctx,
+		`INSERT INTO "user"
+		(id, full_name, email, password, role_code,
+		 created_at, created_by, updated_at, updated_by,
+		 deleted_at, deleted_by, is_deleted)
+		VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12)`,
+		user.Id,
+		user.FullName,
+		user.Email,
+		user.Password,
+		user.RoleCode,
+		user.CreatedAt,
+		user.CreatedBy,
+		user.UpdatedAt,
+		user.UpdatedBy,
+		user.DeletedAt,
+		user.DeletedBy,
+		user.IsDeleted, // ini harus sesuai struct entity
+	)
+
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (ar *authRepository) UpdateUserPassword(ctx context.Context, userId string, hashNewPassword string, updatedBy string) error {
+
+	_, err := ar.db.ExecContext(
+		ctx,
+		`UPDATE "user" SET password = $1, updated_at = $2, updated_by = $3 WHERE id = $4`,
+		hashNewPassword,
+		time.Now(),
+		updatedBy,
+		userId,
+	)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (ar *authRepository) UpdateUserEmailVerified(ctx context.Context, userId string, verifiedAt time.Time, updatedBy string) error {
+
+	_, err := ar.db.ExecContext(
+		ctx,
+		`UPDATE "user" SET email_verified_at = $1, updated_at = $2, updated_by = $3 WHERE id = $4`,
+		verifiedAt,
+		time.Now(),
+		updatedBy,
+		userId,
+	)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (ar *authRepository) UpdateUserProfile(ctx context.Context, userId string, fullName string, phoneNumber *string, address *string, updatedBy string) error {
+
+	_, err := ar.db.ExecContext(
+		ctx,
+		`UPDATE "user" SET full_name = $1, phone_number = $2, address = $3, updated_at = $4, updated_by = $5 WHERE id = $6`,
+		fullName,
+		phoneNumber,
+		address,
+		time.Now(),
+		updatedBy,
+		userId,
+	)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (ar *authRepository) UpdateUserEmail(ctx context.Context, userId string, email string, verifiedAt time.Time, updatedBy string) error {
+
+	_, err := ar.db.ExecContext(
+		ctx,
+		`UPDATE "user" SET email = $1, email_verified_at = $2, updated_at = $3, updated_by = $4 WHERE id = $5`,
+		email,
+		verifiedAt,
+		time.Now(),
+		updatedBy,
+		userId,
+	)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (ar *authRepository) UpdateUserTotpSecret(ctx context.Context, userId string, secret *string, updatedBy string) error {
+
+	_, err := ar.db.ExecContext(
+		ctx,
+		`UPDATE "user" SET totp_secret = $1, totp_enabled_at = NULL, totp_last_used_step = NULL, updated_at = $2, updated_by = $3 WHERE id = $4`,
+		secret,
+		time.Now(),
+		updatedBy,
+		userId,
+	)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (ar *authRepository) EnableUserTotp(ctx context.Context, userId string, enabledAt time.Time, updatedBy string) error {
+
+	_, err := ar.db.ExecContext(
+		ctx,
+		`UPDATE "user" SET totp_enabled_at = $1, updated_at = $2, updated_by = $3 WHERE id = $4`,
+		enabledAt,
+		time.Now(),
+		updatedBy,
+		userId,
+	)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (ar *authRepository) UseUserTotpStep(ctx context.Context, userId string, step int64) (bool, error) {
+
+	result, err := ar.db.ExecContext(
+		ctx,
+		`UPDATE "user" SET totp_last_used_step = $1 WHERE id = $2 AND (totp_last_used_step IS NULL OR totp_last_used_step < $1)`,
+		step,
+		userId,
+	)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return affected > 0, nil
+}
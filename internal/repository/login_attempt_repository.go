@@ -0,0 +1,117 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/arthurhzna/Golang_gRPC/internal/entity"
+	"github.com/arthurhzna/Golang_gRPC/pkg/database"
+	"github.com/lib/pq"
+)
+
+type ILoginAttemptRepository interface {
+	GetLoginAttemptsByKeys(ctx context.Context, keys []string) ([]*entity.LoginAttempt, error)
+	// IncrementLoginFailure counts a failed login and returns the new count, the count restarts at 1
+	// when the previous failure is older than windowStart.
+	IncrementLoginFailure(ctx context.Context, key string, failedAt time.Time, windowStart time.Time) (int, error)
+	LockLoginAttempt(ctx context.Context, key string, lockedUntil time.Time) error
+	DeleteLoginAttempt(ctx context.Context, key string) error
+	// PruneLoginAttempts removes the counters whose last failure is before failedBefore and that are not locked at now.
+	PruneLoginAttempts(ctx context.Context, failedBefore time.Time, now time.Time) (int64, error)
+}
+
+type loginAttemptRepository struct {
+	db database.DatabaseQuery
+}
+
+func NewLoginAttemptRepository(db database.DatabaseQuery) ILoginAttemptRepository {
+	return &loginAttemptRepository{db: db}
+}
+
+func (lr *loginAttemptRepository) GetLoginAttemptsByKeys(ctx context.Context, keys []string) ([]*entity.LoginAttempt, error) {
+	rows, err := lr.db.QueryContext(
+		ctx,
+		`SELECT key, failed_count, last_failed_at, locked_until FROM "login_attempt" WHERE key = ANY($1)`,
+		pq.Array(keys),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var loginAttempts = make([]*entity.LoginAttempt, 0)
+	for rows.Next() {
+		var loginAttempt entity.LoginAttempt
+		err = rows.Scan(
+			&loginAttempt.Key,
+			&loginAttempt.FailedCount,
+			&loginAttempt.LastFailedAt,
+			&loginAttempt.LockedUntil,
+		)
+		if err != nil {
+			return nil, err
+		}
+		loginAttempts = append(loginAttempts, &loginAttempt)
+	}
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+	return loginAttempts, nil
+}
+
+func (lr *loginAttemptRepository) IncrementLoginFailure(ctx context.Context, key string, failedAt time.Time, windowStart time.Time) (int, error) {
+	var failedCount int
+	err := lr.db.QueryRowContext(
+		ctx,
+		`INSERT INTO "login_attempt" (key, failed_count, last_failed_at) VALUES ($1, 1, $2)
+		 ON CONFLICT (key) DO UPDATE SET
+		 failed_count = CASE WHEN login_attempt.last_failed_at < $3 THEN 1 ELSE login_attempt.failed_count + 1 END,
+		 last_failed_at = $2
+		 RETURNING failed_count`,
+		key,
+		failedAt,
+		windowStart,
+	).Scan(&failedCount)
+	if err != nil {
+		return 0, err
+	}
+	return failedCount, nil
+}
+
+func (lr *loginAttemptRepository) LockLoginAttempt(ctx context.Context, key string, lockedUntil time.Time) error {
+	_, err := lr.db.ExecContext(
+		ctx,
+		`UPDATE "login_attempt" SET locked_until = $1 WHERE key = $2`,
+		lockedUntil,
+		key,
+	)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (lr *loginAttemptRepository) DeleteLoginAttempt(ctx context.Context, key string) error {
+	_, err := lr.db.ExecContext(
+		ctx,
+		`DELETE FROM "login_attempt" WHERE key = $1`,
+		key,
+	)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (lr *loginAttemptRepository) PruneLoginAttempts(ctx context.Context, failedBefore time.Time, now time.Time) (int64, error) {
+	result, err := lr.db.ExecContext(
+		ctx,
+		`DELETE FROM "login_attempt" WHERE last_failed_at < $1 AND (locked_until IS NULL OR locked_until < $2)`,
+		failedBefore,
+		now,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
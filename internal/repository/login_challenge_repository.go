@@ -0,0 +1,101 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/arthurhzna/Golang_gRPC/internal/entity"
+	"github.com/arthurhzna/Golang_gRPC/pkg/database"
+)
+
+type ILoginChallengeRepository interface {
+	WithTransaction(tx *sql.Tx) ILoginChallengeRepository
+	CreateLoginChallenge(ctx context.Context, loginChallenge *entity.LoginChallenge) error
+	GetLoginChallengeByHashForUpdate(ctx context.Context, tokenHash string) (*entity.LoginChallenge, error)
+	UseLoginChallenge(ctx context.Context, id string, usedAt time.Time) error
+	IncrementLoginChallengeFailure(ctx context.Context, id string) error
+}
+
+type loginChallengeRepository struct {
+	db database.DatabaseQuery
+}
+
+func NewLoginChallengeRepository(db database.DatabaseQuery) ILoginChallengeRepository {
+	return &loginChallengeRepository{db: db}
+}
+
+func (lr *loginChallengeRepository) WithTransaction(tx *sql.Tx) ILoginChallengeRepository {
+	return &loginChallengeRepository{db: tx}
+}
+
+func (lr *loginChallengeRepository) CreateLoginChallenge(ctx context.Context, loginChallenge *entity.LoginChallenge) error {
+	_, err := lr.db.ExecContext(
+		ctx,
+		`INSERT INTO "login_challenge" (id, user_id, token_hash, expires_at, created_at) VALUES ($1, $2, $3, $4, $5)`,
+		loginChallenge.Id,
+		loginChallenge.UserId,
+		loginChallenge.TokenHash,
+		loginChallenge.ExpiresAt,
+		loginChallenge.CreatedAt,
+	)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (lr *loginChallengeRepository) GetLoginChallengeByHashForUpdate(ctx context.Context, tokenHash string) (*entity.LoginChallenge, error) {
+	row := lr.db.QueryRowContext(
+		ctx,
+		`SELECT id, user_id, token_hash, expires_at, created_at, used_at, failed_count FROM "login_challenge" WHERE token_hash = $1 FOR UPDATE`,
+		tokenHash,
+	)
+	if row.Err() != nil {
+		return nil, row.Err()
+	}
+
+	var loginChallenge entity.LoginChallenge
+	err := row.Scan(
+		&loginChallenge.Id,
+		&loginChallenge.UserId,
+		&loginChallenge.TokenHash,
+		&loginChallenge.ExpiresAt,
+		&loginChallenge.CreatedAt,
+		&loginChallenge.UsedAt,
+		&loginChallenge.FailedCount,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &loginChallenge, nil
+}
+
+func (lr *loginChallengeRepository) UseLoginChallenge(ctx context.Context, id string, usedAt time.Time) error {
+	_, err := lr.db.ExecContext(
+		ctx,
+		`UPDATE "login_challenge" SET used_at = $1 WHERE id = $2`,
+		usedAt,
+		id,
+	)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (lr *loginChallengeRepository) IncrementLoginChallengeFailure(ctx context.Context, id string) error {
+	_, err := lr.db.ExecContext(
+		ctx,
+		`UPDATE "login_challenge" SET failed_count = failed_count + 1 WHERE id = $1`,
+		id,
+	)
+	if err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,91 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/arthurhzna/Golang_gRPC/internal/entity"
+	"github.com/arthurhzna/Golang_gRPC/pkg/database"
+)
+
+type IOidcLoginStateRepository interface {
+	WithTransaction(tx *sql.Tx) IOidcLoginStateRepository
+	CreateOidcLoginState(ctx context.Context, oidcLoginState *entity.OidcLoginState) error
+	GetOidcLoginStateByHashForUpdate(ctx context.Context, stateHash string) (*entity.OidcLoginState, error)
+	UseOidcLoginState(ctx context.Context, id string, usedAt time.Time) error
+}
+
+type oidcLoginStateRepository struct {
+	db database.DatabaseQuery
+}
+
+func NewOidcLoginStateRepository(db database.DatabaseQuery) IOidcLoginStateRepository {
+	return &oidcLoginStateRepository{db: db}
+}
+
+func (sr *oidcLoginStateRepository) WithTransaction(tx *sql.Tx) IOidcLoginStateRepository {
+	return &oidcLoginStateRepository{db: tx}
+}
+
+func (sr *oidcLoginStateRepository) CreateOidcLoginState(ctx context.Context, oidcLoginState *entity.OidcLoginState) error {
+	_, err := sr.db.ExecContext(
+		ctx,
+		`INSERT INTO "oidc_login_state" (id, state_hash, provider, nonce, code_verifier, expires_at, created_at) VALUES ($1, $2, $3, $4, $5, $6, $7)`,
+		oidcLoginState.Id,
+		oidcLoginState.StateHash,
+		oidcLoginState.Provider,
+		oidcLoginState.Nonce,
+		oidcLoginState.CodeVerifier,
+		oidcLoginState.ExpiresAt,
+		oidcLoginState.CreatedAt,
+	)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (sr *oidcLoginStateRepository) GetOidcLoginStateByHashForUpdate(ctx context.Context, stateHash string) (*entity.OidcLoginState, error) {
+	row := sr.db.QueryRowContext(
+		ctx,
+		`SELECT id, state_hash, provider, nonce, code_verifier, expires_at, created_at, used_at FROM "oidc_login_state" WHERE state_hash = $1 FOR UPDATE`,
+		stateHash,
+	)
+	if row.Err() != nil {
+		return nil, row.Err()
+	}
+
+	var oidcLoginState entity.OidcLoginState
+	err := row.Scan(
+		&oidcLoginState.Id,
+		&oidcLoginState.StateHash,
+		&oidcLoginState.Provider,
+		&oidcLoginState.Nonce,
+		&oidcLoginState.CodeVerifier,
+		&oidcLoginState.ExpiresAt,
+		&oidcLoginState.CreatedAt,
+		&oidcLoginState.UsedAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &oidcLoginState, nil
+}
+
+func (sr *oidcLoginStateRepository) UseOidcLoginState(ctx context.Context, id string, usedAt time.Time) error {
+	_, err := sr.db.ExecContext(
+		ctx,
+		`UPDATE "oidc_login_state" SET used_at = $1 WHERE id = $2`,
+		usedAt,
+		id,
+	)
+	if err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,94 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/arthurhzna/Golang_gRPC/internal/entity"
+	"github.com/arthurhzna/Golang_gRPC/pkg/database"
+)
+
+type IOneTimeTokenRepository interface {
+	WithTransaction(tx *sql.Tx) IOneTimeTokenRepository
+	CreateOneTimeToken(ctx context.Context, oneTimeToken *entity.OneTimeToken) error
+	GetOneTimeTokenByHashForUpdate(ctx context.Context, purpose string, tokenHash string) (*entity.OneTimeToken, error)
+	// UseOneTimeTokensByUserId marks every unused token of the user for the purpose as used, including the one just redeemed.
+	UseOneTimeTokensByUserId(ctx context.Context, purpose string, userId string, usedAt time.Time) error
+}
+
+type oneTimeTokenRepository struct {
+	db database.DatabaseQuery
+}
+
+func NewOneTimeTokenRepository(db database.DatabaseQuery) IOneTimeTokenRepository {
+	return &oneTimeTokenRepository{db: db}
+}
+
+func (otr *oneTimeTokenRepository) WithTransaction(tx *sql.Tx) IOneTimeTokenRepository {
+	return &oneTimeTokenRepository{db: tx}
+}
+
+func (otr *oneTimeTokenRepository) CreateOneTimeToken(ctx context.Context, oneTimeToken *entity.OneTimeToken) error {
+	_, err := otr.db.ExecContext(
+		ctx,
+		`INSERT INTO "one_time_token" (id, user_id, purpose, token_hash, new_email, expires_at, created_at) VALUES ($1, $2, $3, $4, $5, $6, $7)`,
+		oneTimeToken.Id,
+		oneTimeToken.UserId,
+		oneTimeToken.Purpose,
+		oneTimeToken.TokenHash,
+		oneTimeToken.NewEmail,
+		oneTimeToken.ExpiresAt,
+		oneTimeToken.CreatedAt,
+	)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (otr *oneTimeTokenRepository) GetOneTimeTokenByHashForUpdate(ctx context.Context, purpose string, tokenHash string) (*entity.OneTimeToken, error) {
+	row := otr.db.QueryRowContext(
+		ctx,
+		`SELECT id, user_id, purpose, token_hash, new_email, expires_at, created_at, used_at FROM "one_time_token" WHERE token_hash = $1 AND purpose = $2 FOR UPDATE`,
+		tokenHash,
+		purpose,
+	)
+	if row.Err() != nil {
+		return nil, row.Err()
+	}
+
+	var oneTimeToken entity.OneTimeToken
+	err := row.Scan(
+		&oneTimeToken.Id,
+		&oneTimeToken.UserId,
+		&oneTimeToken.Purpose,
+		&oneTimeToken.TokenHash,
+		&oneTimeToken.NewEmail,
+		&oneTimeToken.ExpiresAt,
+		&oneTimeToken.CreatedAt,
+		&oneTimeToken.UsedAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &oneTimeToken, nil
+}
+
+func (otr *oneTimeTokenRepository) UseOneTimeTokensByUserId(ctx context.Context, purpose string, userId string, usedAt time.Time) error {
+	_, err := otr.db.ExecContext(
+		ctx,
+		`UPDATE "one_time_token" SET used_at = $1 WHERE user_id = $2 AND purpose = $3 AND used_at IS NULL`,
+		usedAt,
+		userId,
+		purpose,
+	)
+	if err != nil {
+		return err
+	}
+	return nil
+}
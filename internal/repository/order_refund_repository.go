@@ -0,0 +1,157 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/arthurhzna/Golang_gRPC/internal/entity"
+	"github.com/arthurhzna/Golang_gRPC/pkg/database"
+)
+
+type IOrderRefundRepository interface {
+	WithTransaction(tx *sql.Tx) IOrderRefundRepository
+	CreateOrderRefund(ctx context.Context, orderRefund *entity.OrderRefund) error
+	CreateOrderRefundItem(ctx context.Context, orderRefundItem *entity.OrderRefundItem) error
+	GetPendingOrderRefundByOrderId(ctx context.Context, orderId string) (*entity.OrderRefund, error)
+	GetOrderRefundByIdForUpdate(ctx context.Context, orderRefundId string) (*entity.OrderRefund, error)
+	UpdateOrderRefundResult(ctx context.Context, orderRefund *entity.OrderRefund) error
+}
+
+type orderRefundRepository struct {
+	db database.DatabaseQuery
+}
+
+func NewOrderRefundRepository(db database.DatabaseQuery) IOrderRefundRepository {
+	return &orderRefundRepository{db: db}
+}
+
+func (orr *orderRefundRepository) WithTransaction(tx *sql.Tx) IOrderRefundRepository {
+	return &orderRefundRepository{db: tx}
+}
+
+func (orr *orderRefundRepository) CreateOrderRefund(ctx context.Context, orderRefund *entity.OrderRefund) error {
+	_, err := orr.db.ExecContext(
+		ctx,
+		`INSERT INTO "order_refund" (id, order_id, amount, reason, restock, status, payment_provider, payment_refund_id, created_at, created_by) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`,
+		orderRefund.Id,
+		orderRefund.OrderId,
+		orderRefund.Amount,
+		orderRefund.Reason,
+		orderRefund.Restock,
+		orderRefund.Status,
+		orderRefund.PaymentProvider,
+		orderRefund.PaymentRefundId,
+		orderRefund.CreatedAt,
+		orderRefund.CreatedBy,
+	)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (orr *orderRefundRepository) CreateOrderRefundItem(ctx context.Context, orderRefundItem *entity.OrderRefundItem) error {
+	_, err := orr.db.ExecContext(
+		ctx,
+		`INSERT INTO "order_refund_item" (id, order_refund_id, product_id, quantity, amount, created_at) VALUES ($1, $2, $3, $4, $5, $6)`,
+		orderRefundItem.Id,
+		orderRefundItem.OrderRefundId,
+		orderRefundItem.ProductId,
+		orderRefundItem.Quantity,
+		orderRefundItem.Amount,
+		orderRefundItem.CreatedAt,
+	)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (orr *orderRefundRepository) GetPendingOrderRefundByOrderId(ctx context.Context, orderId string) (*entity.OrderRefund, error) {
+	return orr.getOrderRefund(ctx, "order_id = $1 AND status = $2 ORDER BY created_at LIMIT 1", orderId, entity.OrderRefundStatusPending)
+}
+
+func (orr *orderRefundRepository) GetOrderRefundByIdForUpdate(ctx context.Context, orderRefundId string) (*entity.OrderRefund, error) {
+	return orr.getOrderRefund(ctx, "id = $1 FOR UPDATE", orderRefundId)
+}
+
+func (orr *orderRefundRepository) getOrderRefund(ctx context.Context, condition string, args ...any) (*entity.OrderRefund, error) {
+	row := orr.db.QueryRowContext(
+		ctx,
+		fmt.Sprintf(`SELECT id, order_id, amount, reason, restock, status, payment_provider, payment_refund_id, created_at, created_by, updated_at FROM "order_refund" WHERE %s`, condition),
+		args...,
+	)
+	if row.Err() != nil {
+		return nil, row.Err()
+	}
+
+	var orderRefund entity.OrderRefund
+	err := row.Scan(
+		&orderRefund.Id,
+		&orderRefund.OrderId,
+		&orderRefund.Amount,
+		&orderRefund.Reason,
+		&orderRefund.Restock,
+		&orderRefund.Status,
+		&orderRefund.PaymentProvider,
+		&orderRefund.PaymentRefundId,
+		&orderRefund.CreatedAt,
+		&orderRefund.CreatedBy,
+		&orderRefund.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	rows, err := orr.db.QueryContext(
+		ctx,
+		`SELECT id, order_refund_id, product_id, quantity, amount, created_at FROM "order_refund_item" WHERE order_refund_id = $1 ORDER BY created_at, id`,
+		orderRefund.Id,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var item entity.OrderRefundItem
+		err = rows.Scan(
+			&item.Id,
+			&item.OrderRefundId,
+			&item.ProductId,
+			&item.Quantity,
+			&item.Amount,
+			&item.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		orderRefund.Items = append(orderRefund.Items, &item)
+	}
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+
+	return &orderRefund, nil
+}
+
+func (orr *orderRefundRepository) UpdateOrderRefundResult(ctx context.Context, orderRefund *entity.OrderRefund) error {
+	_, err := orr.db.ExecContext(
+		ctx,
+		`UPDATE "order_refund" SET status = $1, payment_provider = $2, payment_refund_id = $3, updated_at = $4 WHERE id = $5`,
+		orderRefund.Status,
+		orderRefund.PaymentProvider,
+		orderRefund.PaymentRefundId,
+		orderRefund.UpdatedAt,
+		orderRefund.Id,
+	)
+	if err != nil {
+		return err
+	}
+	return nil
+}
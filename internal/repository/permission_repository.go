@@ -0,0 +1,41 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/arthurhzna/Golang_gRPC/pkg/database"
+)
+
+type IPermissionRepository interface {
+	GetPermissionCodesByRoleCode(ctx context.Context, roleCode string) ([]string, error)
+}
+
+type permissionRepository struct {
+	db database.DatabaseQuery
+}
+
+func NewPermissionRepository(db database.DatabaseQuery) IPermissionRepository {
+	return &permissionRepository{db: db}
+}
+
+func (pr *permissionRepository) GetPermissionCodesByRoleCode(ctx context.Context, roleCode string) ([]string, error) {
+	rows, err := pr.db.QueryContext(
+		ctx,
+		"SELECT rp.permission_code FROM role_permission rp JOIN permission p ON rp.permission_code = p.code WHERE rp.role_code = $1 AND rp.is_deleted = false AND p.is_deleted = false",
+		roleCode,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	codes := make([]string, 0)
+	for rows.Next() {
+		var code string
+		err = rows.Scan(&code)
+		if err != nil {
+			return nil, err
+		}
+		codes = append(codes, code)
+	}
+	return codes, nil
+}
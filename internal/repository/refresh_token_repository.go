@@ -0,0 +1,138 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/arthurhzna/Golang_gRPC/internal/entity"
+	"github.com/arthurhzna/Golang_gRPC/pkg/database"
+)
+
+type IRefreshTokenRepository interface {
+	WithTransaction(tx *sql.Tx) IRefreshTokenRepository
+	CreateRefreshToken(ctx context.Context, refreshToken *entity.RefreshToken) error
+	GetRefreshTokenByHashForUpdate(ctx context.Context, tokenHash string) (*entity.RefreshToken, error)
+	MarkRefreshTokenUsed(ctx context.Context, id string, usedAt time.Time, replacedBy string) error
+	RevokeRefreshTokenFamily(ctx context.Context, familyId string, revokedAt time.Time) error
+	RevokeRefreshTokensByUserId(ctx context.Context, userId string, revokedAt time.Time) error
+	// RevokeOtherRefreshTokenFamilies revokes every refresh token of the user outside the family of the current login.
+	RevokeOtherRefreshTokenFamilies(ctx context.Context, userId string, familyId string, revokedAt time.Time) error
+}
+
+type refreshTokenRepository struct {
+	db database.DatabaseQuery
+}
+
+func NewRefreshTokenRepository(db database.DatabaseQuery) IRefreshTokenRepository {
+	return &refreshTokenRepository{db: db}
+}
+
+func (rr *refreshTokenRepository) WithTransaction(tx *sql.Tx) IRefreshTokenRepository {
+	return &refreshTokenRepository{db: tx}
+}
+
+func (rr *refreshTokenRepository) CreateRefreshToken(ctx context.Context, refreshToken *entity.RefreshToken) error {
+	_, err := rr.db.ExecContext(
+		ctx,
+		`INSERT INTO "refresh_token" (id, user_id, family_id, token_hash, expires_at, created_at) VALUES ($1, $2, $3, $4, $5, $6)`,
+		refreshToken.Id,
+		refreshToken.UserId,
+		refreshToken.FamilyId,
+		refreshToken.TokenHash,
+		refreshToken.ExpiresAt,
+		refreshToken.CreatedAt,
+	)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// GetRefreshTokenByHashForUpdate locks the token row, so two refreshes with the same token are applied one at a time
+// and the second one is seen as a reuse.
+func (rr *refreshTokenRepository) GetRefreshTokenByHashForUpdate(ctx context.Context, tokenHash string) (*entity.RefreshToken, error) {
+	row := rr.db.QueryRowContext(
+		ctx,
+		`SELECT id, user_id, family_id, token_hash, expires_at, created_at, used_at, replaced_by, revoked_at FROM "refresh_token" WHERE token_hash = $1 FOR UPDATE`,
+		tokenHash,
+	)
+	if row.Err() != nil {
+		return nil, row.Err()
+	}
+
+	var refreshToken entity.RefreshToken
+	err := row.Scan(
+		&refreshToken.Id,
+		&refreshToken.UserId,
+		&refreshToken.FamilyId,
+		&refreshToken.TokenHash,
+		&refreshToken.ExpiresAt,
+		&refreshToken.CreatedAt,
+		&refreshToken.UsedAt,
+		&refreshToken.ReplacedBy,
+		&refreshToken.RevokedAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &refreshToken, nil
+}
+
+func (rr *refreshTokenRepository) MarkRefreshTokenUsed(ctx context.Context, id string, usedAt time.Time, replacedBy string) error {
+	_, err := rr.db.ExecContext(
+		ctx,
+		`UPDATE "refresh_token" SET used_at = $1, replaced_by = $2 WHERE id = $3`,
+		usedAt,
+		replacedBy,
+		id,
+	)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (rr *refreshTokenRepository) RevokeRefreshTokenFamily(ctx context.Context, familyId string, revokedAt time.Time) error {
+	_, err := rr.db.ExecContext(
+		ctx,
+		`UPDATE "refresh_token" SET revoked_at = $1 WHERE family_id = $2 AND revoked_at IS NULL`,
+		revokedAt,
+		familyId,
+	)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (rr *refreshTokenRepository) RevokeRefreshTokensByUserId(ctx context.Context, userId string, revokedAt time.Time) error {
+	_, err := rr.db.ExecContext(
+		ctx,
+		`UPDATE "refresh_token" SET revoked_at = $1 WHERE user_id = $2 AND revoked_at IS NULL`,
+		revokedAt,
+		userId,
+	)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (rr *refreshTokenRepository) RevokeOtherRefreshTokenFamilies(ctx context.Context, userId string, familyId string, revokedAt time.Time) error {
+	_, err := rr.db.ExecContext(
+		ctx,
+		`UPDATE "refresh_token" SET revoked_at = $1 WHERE user_id = $2 AND family_id <> $3 AND revoked_at IS NULL`,
+		revokedAt,
+		userId,
+		familyId,
+	)
+	if err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,98 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/arthurhzna/Golang_gRPC/internal/entity"
+	"github.com/arthurhzna/Golang_gRPC/pkg/database"
+)
+
+type IServiceAccountRepository interface {
+	CreateServiceAccount(ctx context.Context, serviceAccount *entity.ServiceAccount) error
+	GetServiceAccountById(ctx context.Context, id string) (*entity.ServiceAccount, error)
+	GetServiceAccounts(ctx context.Context) ([]*entity.ServiceAccount, error)
+}
+
+type serviceAccountRepository struct {
+	db database.DatabaseQuery
+}
+
+func NewServiceAccountRepository(db database.DatabaseQuery) IServiceAccountRepository {
+	return &serviceAccountRepository{db: db}
+}
+
+func (sr *serviceAccountRepository) CreateServiceAccount(ctx context.Context, serviceAccount *entity.ServiceAccount) error {
+	_, err := sr.db.ExecContext(
+		ctx,
+		`INSERT INTO "service_account" (id, name, role_code, created_at, created_by) VALUES ($1, $2, $3, $4, $5)`,
+		serviceAccount.Id,
+		serviceAccount.Name,
+		serviceAccount.RoleCode,
+		serviceAccount.CreatedAt,
+		serviceAccount.CreatedBy,
+	)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (sr *serviceAccountRepository) GetServiceAccountById(ctx context.Context, id string) (*entity.ServiceAccount, error) {
+	row := sr.db.QueryRowContext(
+		ctx,
+		`SELECT id, name, role_code, created_at, created_by FROM "service_account" WHERE id = $1`,
+		id,
+	)
+	if row.Err() != nil {
+		return nil, row.Err()
+	}
+
+	var serviceAccount entity.ServiceAccount
+	err := row.Scan(
+		&serviceAccount.Id,
+		&serviceAccount.Name,
+		&serviceAccount.RoleCode,
+		&serviceAccount.CreatedAt,
+		&serviceAccount.CreatedBy,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &serviceAccount, nil
+}
+
+func (sr *serviceAccountRepository) GetServiceAccounts(ctx context.Context) ([]*entity.ServiceAccount, error) {
+	rows, err := sr.db.QueryContext(
+		ctx,
+		`SELECT id, name, role_code, created_at, created_by FROM "service_account" ORDER BY created_at`,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var serviceAccounts []*entity.ServiceAccount = make([]*entity.ServiceAccount, 0)
+	for rows.Next() {
+		var serviceAccount entity.ServiceAccount
+		err = rows.Scan(
+			&serviceAccount.Id,
+			&serviceAccount.Name,
+			&serviceAccount.RoleCode,
+			&serviceAccount.CreatedAt,
+			&serviceAccount.CreatedBy,
+		)
+		if err != nil {
+			return nil, err
+		}
+		serviceAccounts = append(serviceAccounts, &serviceAccount)
+	}
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+	return serviceAccounts, nil
+}
@@ -0,0 +1,76 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/arthurhzna/Golang_gRPC/internal/entity"
+	"github.com/arthurhzna/Golang_gRPC/pkg/database"
+)
+
+type ITotpRecoveryCodeRepository interface {
+	WithTransaction(tx *sql.Tx) ITotpRecoveryCodeRepository
+	CreateTotpRecoveryCode(ctx context.Context, recoveryCode *entity.TotpRecoveryCode) error
+	// UseTotpRecoveryCode marks the unused code of the user as used, it returns false when there is no such code
+	UseTotpRecoveryCode(ctx context.Context, userId string, codeHash string, usedAt time.Time) (bool, error)
+	DeleteTotpRecoveryCodesByUserId(ctx context.Context, userId string) error
+}
+
+type totpRecoveryCodeRepository struct {
+	db database.DatabaseQuery
+}
+
+func NewTotpRecoveryCodeRepository(db database.DatabaseQuery) ITotpRecoveryCodeRepository {
+	return &totpRecoveryCodeRepository{db: db}
+}
+
+func (tr *totpRecoveryCodeRepository) WithTransaction(tx *sql.Tx) ITotpRecoveryCodeRepository {
+	return &totpRecoveryCodeRepository{db: tx}
+}
+
+func (tr *totpRecoveryCodeRepository) CreateTotpRecoveryCode(ctx context.Context, recoveryCode *entity.TotpRecoveryCode) error {
+	_, err := tr.db.ExecContext(
+		ctx,
+		`INSERT INTO "totp_recovery_code" (id, user_id, code_hash, created_at) VALUES ($1, $2, $3, $4)`,
+		recoveryCode.Id,
+		recoveryCode.UserId,
+		recoveryCode.CodeHash,
+		recoveryCode.CreatedAt,
+	)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (tr *totpRecoveryCodeRepository) UseTotpRecoveryCode(ctx context.Context, userId string, codeHash string, usedAt time.Time) (bool, error) {
+	result, err := tr.db.ExecContext(
+		ctx,
+		`UPDATE "totp_recovery_code" SET used_at = $1 WHERE user_id = $2 AND code_hash = $3 AND used_at IS NULL`,
+		usedAt,
+		userId,
+		codeHash,
+	)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return affected > 0, nil
+}
+
+func (tr *totpRecoveryCodeRepository) DeleteTotpRecoveryCodesByUserId(ctx context.Context, userId string) error {
+	_, err := tr.db.ExecContext(
+		ctx,
+		`DELETE FROM "totp_recovery_code" WHERE user_id = $1`,
+		userId,
+	)
+	if err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,74 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/arthurhzna/Golang_gRPC/internal/entity"
+	"github.com/arthurhzna/Golang_gRPC/pkg/database"
+)
+
+type IUserIdentityRepository interface {
+	WithTransaction(tx *sql.Tx) IUserIdentityRepository
+	GetUserIdentity(ctx context.Context, provider string, subject string) (*entity.UserIdentity, error)
+	CreateUserIdentity(ctx context.Context, userIdentity *entity.UserIdentity) error
+}
+
+type userIdentityRepository struct {
+	db database.DatabaseQuery
+}
+
+func NewUserIdentityRepository(db database.DatabaseQuery) IUserIdentityRepository {
+	return &userIdentityRepository{db: db}
+}
+
+func (ur *userIdentityRepository) WithTransaction(tx *sql.Tx) IUserIdentityRepository {
+	return &userIdentityRepository{db: tx}
+}
+
+func (ur *userIdentityRepository) GetUserIdentity(ctx context.Context, provider string, subject string) (*entity.UserIdentity, error) {
+	row := ur.db.QueryRowContext(
+		ctx,
+		`SELECT id, user_id, provider, subject, email, created_at FROM "user_identity" WHERE provider = $1 AND subject = $2`,
+		provider,
+		subject,
+	)
+	if row.Err() != nil {
+		return nil, row.Err()
+	}
+
+	var userIdentity entity.UserIdentity
+	err := row.Scan(
+		&userIdentity.Id,
+		&userIdentity.UserId,
+		&userIdentity.Provider,
+		&userIdentity.Subject,
+		&userIdentity.Email,
+		&userIdentity.CreatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &userIdentity, nil
+}
+
+func (ur *userIdentityRepository) CreateUserIdentity(ctx context.Context, userIdentity *entity.UserIdentity) error {
+	_, err := ur.db.ExecContext(
+		ctx,
+		`INSERT INTO "user_identity" (id, user_id, provider, subject, email, created_at) VALUES ($1, $2, $3, $4, $5, $6)`,
+		userIdentity.Id,
+		userIdentity.UserId,
+		userIdentity.Provider,
+		userIdentity.Subject,
+		userIdentity.Email,
+		userIdentity.CreatedAt,
+	)
+	if err != nil {
+		return err
+	}
+	return nil
+}
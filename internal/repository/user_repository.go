@@ -0,0 +1,254 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/arthurhzna/Golang_gRPC/internal/entity"
+	"github.com/arthurhzna/Golang_gRPC/pb/common"
+	"github.com/arthurhzna/Golang_gRPC/pkg/database"
+)
+
+type UserFilter struct {
+	Search          string
+	RoleCode        string
+	IncludeDisabled bool
+}
+
+// IUserRepository is used by user administration, unlike IAuthRepository it also returns disabled users.
+type IUserRepository interface {
+	WithTransaction(tx *sql.Tx) IUserRepository
+	GetUsersByPagination(ctx context.Context, pagination *common.PaginationRequest, filter *UserFilter) ([]*entity.User, *common.PaginationResponse, error)
+	GetUserById(ctx context.Context, id string) (*entity.User, error)
+	GetUserByIdForUpdate(ctx context.Context, id string) (*entity.User, error)
+	GetUserRoleByCode(ctx context.Context, code string) (*entity.UserRole, error)
+	UpdateUserRole(ctx context.Context, id string, roleCode string, updatedBy string) error
+	DisableUser(ctx context.Context, id string, disabledAt time.Time, disabledBy string) error
+	RestoreUser(ctx context.Context, id string, updatedBy string) error
+}
+
+type userRepository struct {
+	db database.DatabaseQuery
+}
+
+func NewUserRepository(db database.DatabaseQuery) IUserRepository {
+	return &userRepository{db: db}
+}
+
+func (ur *userRepository) WithTransaction(tx *sql.Tx) IUserRepository {
+	return &userRepository{db: tx}
+}
+
+func (ur *userRepository) GetUsersByPagination(ctx context.Context, pagination *common.PaginationRequest, filter *UserFilter) ([]*entity.User, *common.PaginationResponse, error) {
+
+	conditions := make([]string, 0)
+	args := make([]any, 0)
+	if !filter.IncludeDisabled {
+		conditions = append(conditions, "is_deleted = false")
+	}
+	if filter.Search != "" {
+		args = append(args, "%"+filter.Search+"%")
+		conditions = append(conditions, fmt.Sprintf("(full_name ILIKE $%d OR email ILIKE $%d)", len(args), len(args)))
+	}
+	if filter.RoleCode != "" {
+		args = append(args, filter.RoleCode)
+		conditions = append(conditions, fmt.Sprintf("role_code = $%d", len(args)))
+	}
+	whereQuery := ""
+	if len(conditions) > 0 {
+		whereQuery = "WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	row := ur.db.QueryRowContext(
+		ctx,
+		fmt.Sprintf(`SELECT COUNT(*) FROM "user" %s`, whereQuery),
+		args...,
+	)
+	if row.Err() != nil {
+		return nil, nil, row.Err()
+	}
+	var totalCount int
+	err := row.Scan(&totalCount)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	offset := (pagination.CurrentPage - 1) * pagination.ItemPerPage
+	totalPages := (totalCount + int(pagination.ItemPerPage) - 1) / int(pagination.ItemPerPage)
+
+	allowedSorts := map[string]bool{
+		"full_name":  true,
+		"email":      true,
+		"role_code":  true,
+		"created_at": true,
+	}
+
+	orderQuery := "ORDER BY created_at DESC"
+
+	if pagination.Sort != nil && allowedSorts[pagination.Sort.Field] {
+		direction := "asc"
+		if pagination.Sort.Direction == "desc" {
+			direction = "desc"
+		}
+		orderQuery = fmt.Sprintf("ORDER BY %s %s", pagination.Sort.Field, direction)
+	}
+
+	args = append(args, pagination.ItemPerPage, offset)
+	baseQuery := fmt.Sprintf(
+		`SELECT id, full_name, email, role_code, created_at, email_verified_at, is_deleted, deleted_at, deleted_by FROM "user" %s %s LIMIT $%d OFFSET $%d`,
+		whereQuery,
+		orderQuery,
+		len(args)-1,
+		len(args),
+	)
+	rows, err := ur.db.QueryContext(ctx, baseQuery, args...)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer rows.Close()
+
+	var users []*entity.User = make([]*entity.User, 0)
+
+	for rows.Next() {
+		var user entity.User
+		err = rows.Scan(
+			&user.Id,
+			&user.FullName,
+			&user.Email,
+			&user.RoleCode,
+			&user.CreatedAt,
+			&user.EmailVerifiedAt,
+			&user.IsDeleted,
+			&user.DeletedAt,
+			&user.DeletedBy,
+		)
+		if err != nil {
+			return nil, nil, err
+		}
+		users = append(users, &user)
+	}
+	if rows.Err() != nil {
+		return nil, nil, rows.Err()
+	}
+
+	paginationResponse := &common.PaginationResponse{
+		CurrentPage:    pagination.CurrentPage,
+		ItemPerPage:    pagination.ItemPerPage,
+		TotalItemCount: int32(totalCount),
+		TotalPageCount: int32(totalPages),
+	}
+	return users, paginationResponse, nil
+}
+
+func (ur *userRepository) GetUserById(ctx context.Context, id string) (*entity.User, error) {
+	return ur.getUserById(ctx, id, false)
+}
+
+func (ur *userRepository) GetUserByIdForUpdate(ctx context.Context, id string) (*entity.User, error) {
+	return ur.getUserById(ctx, id, true)
+}
+
+func (ur *userRepository) getUserById(ctx context.Context, id string, forUpdate bool) (*entity.User, error) {
+	query := `SELECT id, full_name, email, role_code, created_at, email_verified_at, totp_enabled_at, is_deleted, deleted_at, deleted_by FROM "user" WHERE id = $1`
+	if forUpdate {
+		query += " FOR UPDATE"
+	}
+
+	row := ur.db.QueryRowContext(ctx, query, id)
+	if row.Err() != nil {
+		return nil, row.Err()
+	}
+
+	var user entity.User
+	err := row.Scan(
+		&user.Id,
+		&user.FullName,
+		&user.Email,
+		&user.RoleCode,
+		&user.CreatedAt,
+		&user.EmailVerifiedAt,
+		&user.TotpEnabledAt,
+		&user.IsDeleted,
+		&user.DeletedAt,
+		&user.DeletedBy,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &user, nil
+}
+
+func (ur *userRepository) GetUserRoleByCode(ctx context.Context, code string) (*entity.UserRole, error) {
+	row := ur.db.QueryRowContext(
+		ctx,
+		`SELECT id, name, code FROM "user_role" WHERE code = $1 AND is_deleted = false`,
+		code,
+	)
+	if row.Err() != nil {
+		return nil, row.Err()
+	}
+
+	var userRole entity.UserRole
+	err := row.Scan(
+		&userRole.Id,
+		&userRole.Name,
+		&userRole.Code,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &userRole, nil
+}
+
+func (ur *userRepository) UpdateUserRole(ctx context.Context, id string, roleCode string, updatedBy string) error {
+	_, err := ur.db.ExecContext(
+		ctx,
+		`UPDATE "user" SET role_code = $1, updated_at = $2, updated_by = $3 WHERE id = $4`,
+		roleCode,
+		time.Now(),
+		updatedBy,
+		id,
+	)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (ur *userRepository) DisableUser(ctx context.Context, id string, disabledAt time.Time, disabledBy string) error {
+	_, err := ur.db.ExecContext(
+		ctx,
+		`UPDATE "user" SET is_deleted = true, deleted_at = $1, deleted_by = $2 WHERE id = $3`,
+		disabledAt,
+		disabledBy,
+		id,
+	)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (ur *userRepository) RestoreUser(ctx context.Context, id string, updatedBy string) error {
+	_, err := ur.db.ExecContext(
+		ctx,
+		`UPDATE "user" SET is_deleted = false, deleted_at = NULL, deleted_by = NULL, updated_at = $1, updated_by = $2 WHERE id = $3`,
+		time.Now(),
+		updatedBy,
+		id,
+	)
+	if err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,187 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/arthurhzna/Golang_gRPC/internal/entity"
+	"github.com/arthurhzna/Golang_gRPC/pkg/database"
+)
+
+type IUserSessionRepository interface {
+	WithTransaction(tx *sql.Tx) IUserSessionRepository
+	CreateUserSession(ctx context.Context, userSession *entity.UserSession) error
+	GetUserSessionById(ctx context.Context, id string) (*entity.UserSession, error)
+	// GetActiveUserSessionsByUserId returns the sessions that are neither revoked nor expired, most recently seen first.
+	GetActiveUserSessionsByUserId(ctx context.Context, userId string, now time.Time) ([]*entity.UserSession, error)
+	// UpdateUserSessionExpiresAt moves the expiry along with the refresh token issued by a rotation.
+	UpdateUserSessionExpiresAt(ctx context.Context, id string, expiresAt time.Time, lastSeenAt time.Time) error
+	UpdateUserSessionLastSeen(ctx context.Context, id string, lastSeenAt time.Time) error
+	RevokeUserSession(ctx context.Context, id string, revokedAt time.Time) error
+	RevokeUserSessionsByUserId(ctx context.Context, userId string, revokedAt time.Time) error
+	// RevokeOtherUserSessions revokes every session of the user except the current one.
+	RevokeOtherUserSessions(ctx context.Context, userId string, currentId string, revokedAt time.Time) error
+}
+
+type userSessionRepository struct {
+	db database.DatabaseQuery
+}
+
+func NewUserSessionRepository(db database.DatabaseQuery) IUserSessionRepository {
+	return &userSessionRepository{db: db}
+}
+
+func (ur *userSessionRepository) WithTransaction(tx *sql.Tx) IUserSessionRepository {
+	return &userSessionRepository{db: tx}
+}
+
+func (ur *userSessionRepository) CreateUserSession(ctx context.Context, userSession *entity.UserSession) error {
+	_, err := ur.db.ExecContext(
+		ctx,
+		`INSERT INTO "user_session" (id, user_id, user_agent, ip_address, created_at, last_seen_at, expires_at) VALUES ($1, $2, $3, $4, $5, $6, $7)`,
+		userSession.Id,
+		userSession.UserId,
+		userSession.UserAgent,
+		userSession.IpAddress,
+		userSession.CreatedAt,
+		userSession.LastSeenAt,
+		userSession.ExpiresAt,
+	)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (ur *userSessionRepository) GetUserSessionById(ctx context.Context, id string) (*entity.UserSession, error) {
+	row := ur.db.QueryRowContext(
+		ctx,
+		`SELECT id, user_id, user_agent, ip_address, created_at, last_seen_at, expires_at, revoked_at FROM "user_session" WHERE id = $1`,
+		id,
+	)
+	if row.Err() != nil {
+		return nil, row.Err()
+	}
+
+	userSession, err := scanUserSession(row)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return userSession, nil
+}
+
+func (ur *userSessionRepository) GetActiveUserSessionsByUserId(ctx context.Context, userId string, now time.Time) ([]*entity.UserSession, error) {
+	rows, err := ur.db.QueryContext(
+		ctx,
+		`SELECT id, user_id, user_agent, ip_address, created_at, last_seen_at, expires_at, revoked_at FROM "user_session" WHERE user_id = $1 AND revoked_at IS NULL AND expires_at > $2 ORDER BY last_seen_at DESC`,
+		userId,
+		now,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var userSessions []*entity.UserSession = make([]*entity.UserSession, 0)
+	for rows.Next() {
+		userSession, err := scanUserSession(rows)
+		if err != nil {
+			return nil, err
+		}
+		userSessions = append(userSessions, userSession)
+	}
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+	return userSessions, nil
+}
+
+func (ur *userSessionRepository) UpdateUserSessionExpiresAt(ctx context.Context, id string, expiresAt time.Time, lastSeenAt time.Time) error {
+	_, err := ur.db.ExecContext(
+		ctx,
+		`UPDATE "user_session" SET expires_at = $1, last_seen_at = $2 WHERE id = $3`,
+		expiresAt,
+		lastSeenAt,
+		id,
+	)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (ur *userSessionRepository) UpdateUserSessionLastSeen(ctx context.Context, id string, lastSeenAt time.Time) error {
+	_, err := ur.db.ExecContext(
+		ctx,
+		`UPDATE "user_session" SET last_seen_at = $1 WHERE id = $2`,
+		lastSeenAt,
+		id,
+	)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (ur *userSessionRepository) RevokeUserSession(ctx context.Context, id string, revokedAt time.Time) error {
+	_, err := ur.db.ExecContext(
+		ctx,
+		`UPDATE "user_session" SET revoked_at = $1 WHERE id = $2 AND revoked_at IS NULL`,
+		revokedAt,
+		id,
+	)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (ur *userSessionRepository) RevokeUserSessionsByUserId(ctx context.Context, userId string, revokedAt time.Time) error {
+	_, err := ur.db.ExecContext(
+		ctx,
+		`UPDATE "user_session" SET revoked_at = $1 WHERE user_id = $2 AND revoked_at IS NULL`,
+		revokedAt,
+		userId,
+	)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (ur *userSessionRepository) RevokeOtherUserSessions(ctx context.Context, userId string, currentId string, revokedAt time.Time) error {
+	_, err := ur.db.ExecContext(
+		ctx,
+		`UPDATE "user_session" SET revoked_at = $1 WHERE user_id = $2 AND id <> $3 AND revoked_at IS NULL`,
+		revokedAt,
+		userId,
+		currentId,
+	)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func scanUserSession(scanner interface{ Scan(dest ...any) error }) (*entity.UserSession, error) {
+	var userSession entity.UserSession
+	err := scanner.Scan(
+		&userSession.Id,
+		&userSession.UserId,
+		&userSession.UserAgent,
+		&userSession.IpAddress,
+		&userSession.CreatedAt,
+		&userSession.LastSeenAt,
+		&userSession.ExpiresAt,
+		&userSession.RevokedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &userSession, nil
+}
@@ -0,0 +1,69 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/arthurhzna/Golang_gRPC/internal/entity"
+	"github.com/arthurhzna/Golang_gRPC/pkg/database"
+)
+
+type IWebhookEventRepository interface {
+	WithTransaction(tx *sql.Tx) IWebhookEventRepository
+	CreatePaymentWebhookEvent(ctx context.Context, event *entity.PaymentWebhookEvent) (bool, error)
+	UpdatePaymentWebhookEventResult(ctx context.Context, id string, result string, updatedAt time.Time) error
+}
+
+type webhookEventRepository struct {
+	db database.DatabaseQuery
+}
+
+func NewWebhookEventRepository(db database.DatabaseQuery) IWebhookEventRepository {
+	return &webhookEventRepository{db: db}
+}
+
+func (wr *webhookEventRepository) WithTransaction(tx *sql.Tx) IWebhookEventRepository {
+	return &webhookEventRepository{db: tx}
+}
+
+// CreatePaymentWebhookEvent stores the event and reports whether it was new.
+// A redelivered callback (same provider, invoice id and status) is not inserted again and returns false.
+func (wr *webhookEventRepository) CreatePaymentWebhookEvent(ctx context.Context, event *entity.PaymentWebhookEvent) (bool, error) {
+	result, err := wr.db.ExecContext(
+		ctx,
+		`INSERT INTO "payment_webhook_event" (id, provider, invoice_id, order_id, status, amount, payload, result, created_at) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9) ON CONFLICT (provider, invoice_id, status) DO NOTHING`,
+		event.Id,
+		event.Provider,
+		event.InvoiceId,
+		event.OrderId,
+		event.Status,
+		event.Amount,
+		event.Payload,
+		event.Result,
+		event.CreatedAt,
+	)
+	if err != nil {
+		return false, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return rowsAffected > 0, nil
+}
+
+func (wr *webhookEventRepository) UpdatePaymentWebhookEventResult(ctx context.Context, id string, result string, updatedAt time.Time) error {
+	_, err := wr.db.ExecContext(
+		ctx,
+		`UPDATE "payment_webhook_event" SET result = $1, updated_at = $2 WHERE id = $3`,
+		result,
+		updatedAt,
+		id,
+	)
+	if err != nil {
+		return err
+	}
+	return nil
+}
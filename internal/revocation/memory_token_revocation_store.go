@@ -0,0 +1,49 @@
+package revocation
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// memoryTokenRevocationStore only sees revocations of its own process, it is meant for tests and local runs.
+type memoryTokenRevocationStore struct {
+	mu      sync.Mutex
+	revoked map[string]time.Time
+}
+
+func NewMemoryTokenRevocationStore() TokenRevocationStore {
+	return &memoryTokenRevocationStore{
+		revoked: make(map[string]time.Time),
+	}
+}
+
+func (ms *memoryTokenRevocationStore) Revoke(ctx context.Context, jti string, expiresAt time.Time) error {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+
+	ms.revoked[jti] = expiresAt
+	return nil
+}
+
+func (ms *memoryTokenRevocationStore) IsRevoked(ctx context.Context, jti string) (bool, error) {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+
+	expiresAt, ok := ms.revoked[jti]
+	return ok && time.Now().Before(expiresAt), nil
+}
+
+func (ms *memoryTokenRevocationStore) PruneExpired(ctx context.Context, now time.Time) (int64, error) {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+
+	var pruned int64
+	for jti, expiresAt := range ms.revoked {
+		if !now.Before(expiresAt) {
+			delete(ms.revoked, jti)
+			pruned++
+		}
+	}
+	return pruned, nil
+}
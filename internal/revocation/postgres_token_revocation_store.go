@@ -0,0 +1,57 @@
+package revocation
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+// postgresTokenRevocationStore keeps revocations in the revoked_token table, so a logout survives restarts
+// and is seen by every gRPC replica.
+type postgresTokenRevocationStore struct {
+	db *sql.DB
+}
+
+func NewPostgresTokenRevocationStore(db *sql.DB) TokenRevocationStore {
+	return &postgresTokenRevocationStore{db: db}
+}
+
+func (ps *postgresTokenRevocationStore) Revoke(ctx context.Context, jti string, expiresAt time.Time) error {
+	_, err := ps.db.ExecContext(
+		ctx,
+		`INSERT INTO "revoked_token" (jti, expires_at, revoked_at) VALUES ($1, $2, $3) ON CONFLICT (jti) DO NOTHING`,
+		jti,
+		expiresAt,
+		time.Now(),
+	)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (ps *postgresTokenRevocationStore) IsRevoked(ctx context.Context, jti string) (bool, error) {
+	var revoked bool
+	err := ps.db.QueryRowContext(
+		ctx,
+		`SELECT EXISTS (SELECT 1 FROM "revoked_token" WHERE jti = $1 AND expires_at > $2)`,
+		jti,
+		time.Now(),
+	).Scan(&revoked)
+	if err != nil {
+		return false, err
+	}
+	return revoked, nil
+}
+
+func (ps *postgresTokenRevocationStore) PruneExpired(ctx context.Context, now time.Time) (int64, error) {
+	result, err := ps.db.ExecContext(
+		ctx,
+		`DELETE FROM "revoked_token" WHERE expires_at <= $1`,
+		now,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
@@ -0,0 +1,15 @@
+package revocation
+
+import (
+	"context"
+	"time"
+)
+
+// TokenRevocationStore keeps the jti of access tokens revoked before they expire.
+// Entries are only needed until the token's own ExpiresAt, after that the signature check already rejects it.
+type TokenRevocationStore interface {
+	Revoke(ctx context.Context, jti string, expiresAt time.Time) error
+	IsRevoked(ctx context.Context, jti string) (bool, error)
+	// PruneExpired removes the entries whose token expired before now and returns how many were removed.
+	PruneExpired(ctx context.Context, now time.Time) (int64, error)
+}
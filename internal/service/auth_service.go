@@ -1,245 +1,1643 @@
-package service
-
-import (
-	"context"
-	"errors"
-	"os"
-	"time"
-
-	"golang.org/x/crypto/bcrypt"
-	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/status"
-	"google.golang.org/protobuf/types/known/timestamppb"
-
-	"github.com/arthurhzna/Golang_gRPC/internal/entity"
-	jwtentity "github.com/arthurhzna/Golang_gRPC/internal/entity/jwt"
-	"github.com/arthurhzna/Golang_gRPC/internal/repository"
-	"github.com/arthurhzna/Golang_gRPC/internal/utils"
-	"github.com/arthurhzna/Golang_gRPC/pb/auth"
-	"github.com/golang-jwt/jwt/v5"
-	"github.com/google/uuid"
-	"github.com/patrickmn/go-cache"
-)
-
-type IAuthService interface {
-	Register(ctx context.Context, req *auth.RegisterRequest) (*auth.RegisterResponse, error)
-	Login(ctx context.Context, req *auth.LoginRequest) (*auth.LoginResponse, error)
-	Logout(ctx context.Context, req *auth.LogoutRequest) (*auth.LogoutResponse, error)
-	ChangePassword(ctx context.Context, req *auth.ChangePasswordRequest) (*auth.ChangePasswordResponse, error)
-	GetProfile(ctx context.Context, req *auth.GetProfileRequest) (*auth.GetProfileResponse, error)
-}
-
-type authService struct {
-	authRepository repository.IAuthRepository
-	cacheService   *cache.Cache
-}
-
-func NewAuthService(authRepository repository.IAuthRepository, cacheService *cache.Cache) IAuthService {
-	return &authService{
-		authRepository: authRepository,
-		cacheService:   cacheService,
-	}
-}
-
-func (as *authService) Register(ctx context.Context, req *auth.RegisterRequest) (*auth.RegisterResponse, error) {
-
-	if req.Password != req.PasswordConfirmation {
-		return &auth.RegisterResponse{
-			Base: utils.BadRequestResponse("Password and password confirmation do not match"),
-		}, nil
-	}
-
-	user, err := as.authRepository.GetUserByEmail(ctx, req.Email)
-	if err != nil {
-		return nil, err
-	}
-	if user != nil {
-		return &auth.RegisterResponse{
-			Base: utils.BadRequestResponse("User already exists"),
-		}, nil
-	}
-
-	hashPassword, err := bcrypt.GenerateFromPassword([]byte(req.Password), 10)
-	if err != nil {
-		return nil, err
-	}
-
-	NewUser := &entity.User{
-		Id:        uuid.New().String(),
-		FullName:  req.FullName,
-		Email:     req.Email,
-		Password:  string(hashPassword),
-		RoleCode:  entity.UserRoleCustomer,
-		CreatedAt: time.Now(),
-		CreatedBy: &req.FullName,
-	}
-
-	err = as.authRepository.InsertUser(ctx, NewUser)
-	if err != nil {
-		return nil, err
-	}
-
-	return &auth.RegisterResponse{
-		Base: utils.SuccessResponse("User registered successfully"),
-	}, nil
-}
-
-func (as *authService) Login(ctx context.Context, req *auth.LoginRequest) (*auth.LoginResponse, error) {
-
-	user, err := as.authRepository.GetUserByEmail(ctx, req.Email)
-	if err != nil {
-		return nil, err
-	}
-	if user == nil {
-		return &auth.LoginResponse{
-			Base: utils.BadRequestResponse("User not found"),
-		}, nil
-	}
-
-	err = bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(req.Password))
-	if err != nil {
-		if errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) {
-			return nil, status.Errorf(codes.Unauthenticated, "Invalid password")
-		}
-		return nil, err
-	}
-
-	now := time.Now()
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwtentity.JwtClaims{
-		RegisteredClaims: jwt.RegisteredClaims{
-			Subject:   user.Id,
-			ExpiresAt: jwt.NewNumericDate(now.Add(time.Hour * 24)),
-			IssuedAt:  jwt.NewNumericDate(now),
-		},
-		Email:    user.Email,
-		FullName: user.FullName,
-		Role:     user.RoleCode,
-	})
-	secretKey := os.Getenv("JWT_SECRET")
-	accessToken, err := token.SignedString([]byte(secretKey))
-	if err != nil {
-		return nil, err
-	}
-
-	return &auth.LoginResponse{
-		Base:        utils.SuccessResponse("Login successful"),
-		AccessToken: accessToken,
-	}, nil
-}
-
-func (as *authService) Logout(ctx context.Context, req *auth.LogoutRequest) (*auth.LogoutResponse, error) {
-
-	jwtToken, err := jwtentity.ParseTokenFromContext(ctx)
-	if err != nil {
-		return nil, err
-	}
-
-	claims, err := jwtentity.GetClaimsFromToken(jwtToken)
-	if err != nil {
-		return nil, err
-	}
-
-	as.cacheService.Set(jwtToken, "", time.Duration(claims.ExpiresAt.Unix()-time.Now().Unix())*time.Second)
-
-	return &auth.LogoutResponse{
-		Base: utils.SuccessResponse("Logout successful"),
-	}, nil
-}
-
-func (as *authService) ChangePassword(ctx context.Context, req *auth.ChangePasswordRequest) (*auth.ChangePasswordResponse, error) {
-	if req.NewPassword != req.NewPasswordConfirmation {
-		return &auth.ChangePasswordResponse{
-			Base: utils.BadRequestResponse("New password and new password confirmation do not match"),
-		}, nil
-	}
-
-	// jwtToken, err := jwtentity.ParseTokenFromContext(ctx)
-	// if err != nil {
-	// 	return nil, err
-	// }
-
-	// claims, err := jwtentity.GetClaimsFromToken(jwtToken)
-	// if err != nil {
-	// 	return nil, err
-	// }
-
-	claims, err := jwtentity.GetClaimsFromContext(ctx)
-	if err != nil {
-		return nil, err
-	}
-
-	user, err := as.authRepository.GetUserByEmail(ctx, claims.Email)
-	if err != nil {
-		return nil, err
-	}
-	if user == nil {
-		return &auth.ChangePasswordResponse{
-			Base: utils.BadRequestResponse("User not found"),
-		}, nil
-	}
-
-	err = bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(req.OldPassword))
-	if err != nil {
-		if errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) {
-			return &auth.ChangePasswordResponse{
-				Base: utils.BadRequestResponse("Old password is incorrect"),
-			}, nil
-		}
-		return nil, err
-	}
-
-	hashNewPassword, err := bcrypt.GenerateFromPassword([]byte(req.NewPassword), 10)
-	if err != nil {
-		return nil, err
-	}
-
-	err = as.authRepository.UpdateUserPassword(ctx, user.Id, string(hashNewPassword), user.FullName)
-	if err != nil {
-		return nil, err
-	}
-
-	return &auth.ChangePasswordResponse{
-		Base: utils.SuccessResponse("Password changed successfully"),
-	}, nil
-
-}
-
-func (as *authService) GetProfile(ctx context.Context, req *auth.GetProfileRequest) (*auth.GetProfileResponse, error) {
-
-	claims, err := jwtentity.GetClaimsFromContext(ctx)
-	if err != nil {
-		return nil, err
-	}
-
-	user, err := as.authRepository.GetUserByEmail(ctx, claims.Email)
-	if err != nil {
-		return nil, err
-	}
-	if user == nil {
-		return &auth.GetProfileResponse{
-			Base: utils.BadRequestResponse("User not found"),
-		}, nil
-	}
-
-	user, err = as.authRepository.GetUserByEmail(ctx, claims.Email)
-
-	if err != nil {
-		return nil, err
-	}
-
-	if user == nil {
-		return &auth.GetProfileResponse{
-			Base: utils.BadRequestResponse("User not found"),
-		}, nil
-	}
-
-	return &auth.GetProfileResponse{
-		Base:        utils.SuccessResponse("Get Profile Success"),
-		UserId:      user.Id,
-		FullName:    user.FullName,
-		Email:       user.Email,
-		RoleCode:    user.RoleCode,
-		MemberSince: timestamppb.New(user.CreatedAt),
-	}, nil
-
-}
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"os"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/arthurhzna/Golang_gRPC/internal/domainerror"
+	"github.com/arthurhzna/Golang_gRPC/internal/entity"
+	jwtentity "github.com/arthurhzna/Golang_gRPC/internal/entity/jwt"
+	"github.com/arthurhzna/Golang_gRPC/internal/mailer"
+	"github.com/arthurhzna/Golang_gRPC/internal/oidc"
+	"github.com/arthurhzna/Golang_gRPC/internal/repository"
+	"github.com/arthurhzna/Golang_gRPC/internal/revocation"
+	"github.com/arthurhzna/Golang_gRPC/internal/utils"
+	"github.com/arthurhzna/Golang_gRPC/pb/auth"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/pquerna/otp/totp"
+)
+
+type IAuthService interface {
+	Register(ctx context.Context, req *auth.RegisterRequest) (*auth.RegisterResponse, error)
+	Login(ctx context.Context, req *auth.LoginRequest) (*auth.LoginResponse, error)
+	RefreshToken(ctx context.Context, req *auth.RefreshTokenRequest) (*auth.RefreshTokenResponse, error)
+	Logout(ctx context.Context, req *auth.LogoutRequest) (*auth.LogoutResponse, error)
+	ChangePassword(ctx context.Context, req *auth.ChangePasswordRequest) (*auth.ChangePasswordResponse, error)
+	RequestPasswordReset(ctx context.Context, req *auth.RequestPasswordResetRequest) (*auth.RequestPasswordResetResponse, error)
+	ResetPassword(ctx context.Context, req *auth.ResetPasswordRequest) (*auth.ResetPasswordResponse, error)
+	VerifyEmail(ctx context.Context, req *auth.VerifyEmailRequest) (*auth.VerifyEmailResponse, error)
+	ResendVerification(ctx context.Context, req *auth.ResendVerificationRequest) (*auth.ResendVerificationResponse, error)
+	UnlockAccount(ctx context.Context, req *auth.UnlockAccountRequest) (*auth.UnlockAccountResponse, error)
+	GetProfile(ctx context.Context, req *auth.GetProfileRequest) (*auth.GetProfileResponse, error)
+	UpdateProfile(ctx context.Context, req *auth.UpdateProfileRequest) (*auth.UpdateProfileResponse, error)
+	RequestEmailChange(ctx context.Context, req *auth.RequestEmailChangeRequest) (*auth.RequestEmailChangeResponse, error)
+	ConfirmEmailChange(ctx context.Context, req *auth.ConfirmEmailChangeRequest) (*auth.ConfirmEmailChangeResponse, error)
+	VerifyLoginTotp(ctx context.Context, req *auth.VerifyLoginTotpRequest) (*auth.VerifyLoginTotpResponse, error)
+	EnrollTotp(ctx context.Context, req *auth.EnrollTotpRequest) (*auth.EnrollTotpResponse, error)
+	ConfirmTotp(ctx context.Context, req *auth.ConfirmTotpRequest) (*auth.ConfirmTotpResponse, error)
+	DisableTotp(ctx context.Context, req *auth.DisableTotpRequest) (*auth.DisableTotpResponse, error)
+	StartOidcLogin(ctx context.Context, req *auth.StartOidcLoginRequest) (*auth.StartOidcLoginResponse, error)
+	OidcLogin(ctx context.Context, req *auth.OidcLoginRequest) (*auth.OidcLoginResponse, error)
+	ListSessions(ctx context.Context, req *auth.ListSessionsRequest) (*auth.ListSessionsResponse, error)
+	RevokeSession(ctx context.Context, req *auth.RevokeSessionRequest) (*auth.RevokeSessionResponse, error)
+	RevokeAllOtherSessions(ctx context.Context, req *auth.RevokeAllOtherSessionsRequest) (*auth.RevokeAllOtherSessionsResponse, error)
+}
+
+const (
+	accessTokenDuration       = time.Minute * 15
+	refreshTokenDuration      = time.Hour * 24 * 30
+	passwordResetDuration     = time.Hour
+	emailVerificationDuration = time.Hour * 24
+	emailChangeDuration       = time.Hour
+
+	// passwordResetSendTimeout bounds the background send of a reset link, which no request waits for
+	passwordResetSendTimeout = time.Minute
+)
+
+type authService struct {
+	db                         *sql.DB
+	authRepository             repository.IAuthRepository
+	refreshTokenRepository     repository.IRefreshTokenRepository
+	oneTimeTokenRepository     repository.IOneTimeTokenRepository
+	loginAttemptRepository     repository.ILoginAttemptRepository
+	totpRecoveryCodeRepository repository.ITotpRecoveryCodeRepository
+	loginChallengeRepository   repository.ILoginChallengeRepository
+	userIdentityRepository     repository.IUserIdentityRepository
+	oidcLoginStateRepository   repository.IOidcLoginStateRepository
+	userSessionRepository      repository.IUserSessionRepository
+	tokenRevocationStore       revocation.TokenRevocationStore
+	keySet                     *jwtentity.KeySet
+	mailer                     mailer.Mailer
+	emailVerificationPolicy    EmailVerificationPolicy
+	totpPolicy                 TotpPolicy
+	oidcProviders              map[string]oidc.Provider
+}
+
+func NewAuthService(db *sql.DB, authRepository repository.IAuthRepository, refreshTokenRepository repository.IRefreshTokenRepository, oneTimeTokenRepository repository.IOneTimeTokenRepository, loginAttemptRepository repository.ILoginAttemptRepository, totpRecoveryCodeRepository repository.ITotpRecoveryCodeRepository, loginChallengeRepository repository.ILoginChallengeRepository, userIdentityRepository repository.IUserIdentityRepository, oidcLoginStateRepository repository.IOidcLoginStateRepository, userSessionRepository repository.IUserSessionRepository, tokenRevocationStore revocation.TokenRevocationStore, keySet *jwtentity.KeySet, mailer mailer.Mailer, emailVerificationPolicy EmailVerificationPolicy, totpPolicy TotpPolicy, oidcProviders map[string]oidc.Provider) IAuthService {
+	return &authService{
+		db:                         db,
+		authRepository:             authRepository,
+		refreshTokenRepository:     refreshTokenRepository,
+		oneTimeTokenRepository:     oneTimeTokenRepository,
+		loginAttemptRepository:     loginAttemptRepository,
+		totpRecoveryCodeRepository: totpRecoveryCodeRepository,
+		loginChallengeRepository:   loginChallengeRepository,
+		userIdentityRepository:     userIdentityRepository,
+		oidcLoginStateRepository:   oidcLoginStateRepository,
+		userSessionRepository:      userSessionRepository,
+		tokenRevocationStore:       tokenRevocationStore,
+		keySet:                     keySet,
+		mailer:                     mailer,
+		emailVerificationPolicy:    emailVerificationPolicy,
+		totpPolicy:                 totpPolicy,
+		oidcProviders:              oidcProviders,
+	}
+}
+
+func (as *authService) Register(ctx context.Context, req *auth.RegisterRequest) (*auth.RegisterResponse, error) {
+
+	if req.Password != req.PasswordConfirmation {
+		return nil, domainerror.InvalidArgument("PASSWORD_CONFIRMATION_MISMATCH", "Password and password confirmation do not match").WithField("password_confirmation")
+	}
+
+	// disabled users count too, their email must stay theirs until they are restored
+	registered, err := as.authRepository.IsEmailRegistered(ctx, req.Email)
+	if err != nil {
+		return nil, err
+	}
+	if registered {
+		return nil, domainerror.Conflict("USER_ALREADY_EXISTS", "User already exists")
+	}
+
+	hashPassword, err := bcrypt.GenerateFromPassword([]byte(req.Password), 10)
+	if err != nil {
+		return nil, err
+	}
+
+	NewUser := &entity.User{
+		Id:        uuid.New().String(),
+		FullName:  req.FullName,
+		Email:     req.Email,
+		Password:  string(hashPassword),
+		RoleCode:  entity.UserRoleCustomer,
+		CreatedAt: time.Now(),
+		CreatedBy: &req.FullName,
+	}
+
+	err = as.authRepository.InsertUser(ctx, NewUser)
+	if err != nil {
+		return nil, err
+	}
+
+	// the account exists at this point, a failed email can be sent again with ResendVerification
+	err = as.sendEmailVerification(ctx, NewUser)
+	if err != nil {
+		slog.ErrorContext(ctx, "Send email verification failed", "user_id", NewUser.Id, "error", err)
+	}
+
+	return &auth.RegisterResponse{
+		Base: utils.SuccessResponse("User registered successfully, check your email to verify your account"),
+	}, nil
+}
+
+func (as *authService) Login(ctx context.Context, req *auth.LoginRequest) (*auth.LoginResponse, error) {
+
+	now := time.Now()
+	emailKey := loginEmailKey(req.Email)
+	loginAttemptKeys := []string{emailKey}
+	ipKey := ""
+	clientIp := utils.ClientIpFromContext(ctx)
+	if clientIp != "" {
+		ipKey = loginIpKey(clientIp)
+		loginAttemptKeys = append(loginAttemptKeys, ipKey)
+	}
+
+	locked, err := as.isLoginLocked(ctx, loginAttemptKeys, now)
+	if err != nil {
+		return nil, err
+	}
+	if locked {
+		return nil, ErrLoginLocked
+	}
+
+	user, err := as.authRepository.GetUserByEmail(ctx, req.Email)
+	if err != nil {
+		return nil, err
+	}
+
+	// unknown emails and wrong passwords get the same error after the same work
+	passwordHash := dummyPasswordHash
+	if user != nil {
+		passwordHash = []byte(user.Password)
+	}
+	err = bcrypt.CompareHashAndPassword(passwordHash, []byte(req.Password))
+	if user == nil || err != nil {
+		if err != nil && !errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) {
+			return nil, err
+		}
+		err = as.recordLoginFailure(ctx, emailKey, loginEmailMaxFailures, now)
+		if err != nil {
+			return nil, err
+		}
+		if ipKey != "" {
+			err = as.recordLoginFailure(ctx, ipKey, loginIpMaxFailures, now)
+			if err != nil {
+				return nil, err
+			}
+		}
+		return nil, ErrLoginInvalidCredentials
+	}
+
+	if user.EmailVerifiedAt == nil && as.emailVerificationPolicy.BlocksLogin() {
+		return nil, domainerror.FailedPrecondition("EMAIL_NOT_VERIFIED", "Email is not verified, check your email or request a new verification link")
+	}
+
+	return as.completeLogin(ctx, user, now)
+}
+
+func (as *authService) RefreshToken(ctx context.Context, req *auth.RefreshTokenRequest) (*auth.RefreshTokenResponse, error) {
+
+	tx, err := as.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err != nil && tx != nil {
+			tx.Rollback()
+		}
+	}()
+
+	refreshTokenRepo := as.refreshTokenRepository.WithTransaction(tx)
+	userSessionRepo := as.userSessionRepository.WithTransaction(tx)
+
+	now := time.Now()
+	refreshTokenEntity, err := refreshTokenRepo.GetRefreshTokenByHashForUpdate(ctx, hashOpaqueToken(req.RefreshToken))
+	if err != nil {
+		return nil, err
+	}
+	if refreshTokenEntity == nil || refreshTokenEntity.RevokedAt != nil || !now.Before(refreshTokenEntity.ExpiresAt) {
+		tx.Rollback()
+		return nil, utils.UnaunthorizedResponse()
+	}
+
+	// a token that was already rotated is being replayed, the family is treated as stolen
+	if refreshTokenEntity.UsedAt != nil {
+		err = refreshTokenRepo.RevokeRefreshTokenFamily(ctx, refreshTokenEntity.FamilyId, now)
+		if err != nil {
+			return nil, err
+		}
+		err = userSessionRepo.RevokeUserSession(ctx, refreshTokenEntity.FamilyId, now)
+		if err != nil {
+			return nil, err
+		}
+		err = tx.Commit()
+		if err != nil {
+			return nil, err
+		}
+		return nil, utils.UnaunthorizedResponse()
+	}
+
+	user, err := as.authRepository.WithTransaction(tx).GetUserById(ctx, refreshTokenEntity.UserId)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		tx.Rollback()
+		return nil, utils.UnaunthorizedResponse()
+	}
+
+	newRefreshTokenEntity, newRefreshToken, err := as.issueRefreshToken(ctx, refreshTokenRepo, user.Id, refreshTokenEntity.FamilyId, now)
+	if err != nil {
+		return nil, err
+	}
+
+	err = refreshTokenRepo.MarkRefreshTokenUsed(ctx, refreshTokenEntity.Id, now, newRefreshTokenEntity.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	userSession, err := userSessionRepo.GetUserSessionById(ctx, refreshTokenEntity.FamilyId)
+	if err != nil {
+		return nil, err
+	}
+	if userSession != nil && userSession.RevokedAt != nil {
+		tx.Rollback()
+		return nil, utils.UnaunthorizedResponse()
+	}
+	if userSession == nil {
+		// logins made before sessions were recorded become a session on their next refresh
+		err = as.createUserSession(ctx, userSessionRepo, refreshTokenEntity.FamilyId, user.Id, newRefreshTokenEntity.ExpiresAt, now)
+	} else {
+		err = userSessionRepo.UpdateUserSessionExpiresAt(ctx, userSession.Id, newRefreshTokenEntity.ExpiresAt, now)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	accessToken, err := as.signAccessToken(user, refreshTokenEntity.FamilyId, now)
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, err
+	}
+
+	return &auth.RefreshTokenResponse{
+		Base:         utils.SuccessResponse("Refresh token successful"),
+		AccessToken:  accessToken,
+		RefreshToken: newRefreshToken,
+	}, nil
+}
+
+func (as *authService) Logout(ctx context.Context, req *auth.LogoutRequest) (*auth.LogoutResponse, error) {
+
+	claims, err := jwtentity.GetClaimsFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	err = as.tokenRevocationStore.Revoke(ctx, claims.ID, claims.ExpiresAt.Time)
+	if err != nil {
+		return nil, err
+	}
+
+	if claims.SessionId != "" {
+		err = as.revokeSession(ctx, claims.SessionId, time.Now())
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &auth.LogoutResponse{
+		Base: utils.SuccessResponse("Logout successful"),
+	}, nil
+}
+
+func (as *authService) ListSessions(ctx context.Context, req *auth.ListSessionsRequest) (*auth.ListSessionsResponse, error) {
+
+	claims, err := jwtentity.GetClaimsFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	userSessions, err := as.userSessionRepository.GetActiveUserSessionsByUserId(ctx, claims.Subject, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	var data []*auth.SessionItem = make([]*auth.SessionItem, 0)
+	for _, userSession := range userSessions {
+		data = append(data, &auth.SessionItem{
+			Id:         userSession.Id,
+			UserAgent:  userSession.UserAgent,
+			IpAddress:  userSession.IpAddress,
+			CreatedAt:  timestamppb.New(userSession.CreatedAt),
+			LastSeenAt: timestamppb.New(userSession.LastSeenAt),
+			ExpiresAt:  timestamppb.New(userSession.ExpiresAt),
+			Current:    userSession.Id == claims.SessionId,
+		})
+	}
+
+	return &auth.ListSessionsResponse{
+		Base: utils.SuccessResponse("List sessions successfully"),
+		Data: data,
+	}, nil
+}
+
+func (as *authService) RevokeSession(ctx context.Context, req *auth.RevokeSessionRequest) (*auth.RevokeSessionResponse, error) {
+
+	claims, err := jwtentity.GetClaimsFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	userSession, err := as.userSessionRepository.GetUserSessionById(ctx, req.SessionId)
+	if err != nil {
+		return nil, err
+	}
+	// sessions of other users are reported as missing, so their ids cannot be probed
+	if userSession == nil || userSession.UserId != claims.Subject {
+		return nil, domainerror.NotFound("SESSION_NOT_FOUND", "Session not found")
+	}
+	if userSession.RevokedAt != nil || !now.Before(userSession.ExpiresAt) {
+		return nil, domainerror.FailedPrecondition("SESSION_ENDED", "Session has already ended")
+	}
+
+	err = as.revokeSession(ctx, userSession.Id, now)
+	if err != nil {
+		return nil, err
+	}
+
+	return &auth.RevokeSessionResponse{
+		Base: utils.SuccessResponse("Session revoked successfully"),
+	}, nil
+}
+
+func (as *authService) RevokeAllOtherSessions(ctx context.Context, req *auth.RevokeAllOtherSessionsRequest) (*auth.RevokeAllOtherSessionsResponse, error) {
+
+	claims, err := jwtentity.GetClaimsFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := as.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err != nil && tx != nil {
+			tx.Rollback()
+		}
+	}()
+
+	now := time.Now()
+	err = as.refreshTokenRepository.WithTransaction(tx).RevokeOtherRefreshTokenFamilies(ctx, claims.Subject, claims.SessionId, now)
+	if err != nil {
+		return nil, err
+	}
+	err = as.userSessionRepository.WithTransaction(tx).RevokeOtherUserSessions(ctx, claims.Subject, claims.SessionId, now)
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, err
+	}
+
+	return &auth.RevokeAllOtherSessionsResponse{
+		Base: utils.SuccessResponse("Other sessions revoked successfully"),
+	}, nil
+}
+
+func (as *authService) ChangePassword(ctx context.Context, req *auth.ChangePasswordRequest) (*auth.ChangePasswordResponse, error) {
+	if req.NewPassword != req.NewPasswordConfirmation {
+		return nil, domainerror.InvalidArgument("PASSWORD_CONFIRMATION_MISMATCH", "New password and new password confirmation do not match").WithField("new_password_confirmation")
+	}
+
+	// jwtToken, err := jwtentity.ParseTokenFromContext(ctx)
+	// if err != nil {
+	// 	return nil, err
+	// }
+
+	// claims, err := jwtentity.GetClaimsFromToken(jwtToken)
+	// if err != nil {
+	// 	return nil, err
+	// }
+
+	claims, err := jwtentity.GetClaimsFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := as.authRepository.GetUserById(ctx, claims.Subject)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, domainerror.NotFound("USER_NOT_FOUND", "User not found")
+	}
+
+	err = bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(req.OldPassword))
+	if err != nil {
+		if errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) {
+			return nil, domainerror.InvalidArgument("INCORRECT_PASSWORD", "Old password is incorrect").WithField("old_password")
+		}
+		return nil, err
+	}
+
+	hashNewPassword, err := bcrypt.GenerateFromPassword([]byte(req.NewPassword), 10)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := as.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err != nil && tx != nil {
+			tx.Rollback()
+		}
+	}()
+
+	err = as.authRepository.WithTransaction(tx).UpdateUserPassword(ctx, user.Id, string(hashNewPassword), user.FullName)
+	if err != nil {
+		return nil, err
+	}
+
+	// whoever else knew the old password is logged out, the session that changed it stays
+	now := time.Now()
+	err = as.refreshTokenRepository.WithTransaction(tx).RevokeOtherRefreshTokenFamilies(ctx, user.Id, claims.SessionId, now)
+	if err != nil {
+		return nil, err
+	}
+	err = as.userSessionRepository.WithTransaction(tx).RevokeOtherUserSessions(ctx, user.Id, claims.SessionId, now)
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, err
+	}
+
+	return &auth.ChangePasswordResponse{
+		Base: utils.SuccessResponse("Password changed successfully"),
+	}, nil
+
+}
+
+func (as *authService) RequestPasswordReset(ctx context.Context, req *auth.RequestPasswordResetRequest) (*auth.RequestPasswordResetResponse, error) {
+
+	// the response is the same whether the email is registered or not, so it cannot be used to find accounts
+	response := &auth.RequestPasswordResetResponse{
+		Base: utils.SuccessResponse("If the email is registered, a password reset link has been sent"),
+	}
+
+	now := time.Now()
+	locked, err := as.throttleEmailSend(ctx, emailSendPurposePasswordReset, req.Email, passwordResetEmailMaxSends, passwordResetIpMaxSends, now)
+	if err != nil {
+		return nil, err
+	}
+	if locked {
+		return nil, ErrPasswordResetLocked
+	}
+
+	user, err := as.authRepository.GetUserByEmail(ctx, req.Email)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return response, nil
+	}
+
+	// the link is stored and mailed off the request path, so registered and unknown emails answer in the same time
+	go as.sendPasswordReset(context.WithoutCancel(ctx), user, now)
+
+	return response, nil
+}
+
+// sendPasswordReset stores a new reset token for the user and mails its link. It runs after the request answered,
+// so failures are only logged; the user can ask for another link.
+func (as *authService) sendPasswordReset(ctx context.Context, user *entity.User, now time.Time) {
+	ctx, cancel := context.WithTimeout(ctx, passwordResetSendTimeout)
+	defer cancel()
+
+	rawToken, err := generateOpaqueToken()
+	if err != nil {
+		slog.ErrorContext(ctx, "Send password reset failed", "user_id", user.Id, "error", err)
+		return
+	}
+
+	err = as.oneTimeTokenRepository.CreateOneTimeToken(ctx, &entity.OneTimeToken{
+		Id:        uuid.New().String(),
+		UserId:    user.Id,
+		Purpose:   entity.OneTimeTokenPurposePasswordReset,
+		TokenHash: hashOpaqueToken(rawToken),
+		ExpiresAt: now.Add(passwordResetDuration),
+		CreatedAt: now,
+	})
+	if err != nil {
+		slog.ErrorContext(ctx, "Send password reset failed", "user_id", user.Id, "error", err)
+		return
+	}
+
+	resetUrl := fmt.Sprintf("%s/reset-password?token=%s", os.Getenv("FE_BASE_URL"), url.QueryEscape(rawToken))
+	err = as.mailer.Send(ctx, &mailer.Message{
+		To:      user.Email,
+		Subject: "Reset your password",
+		Body:    fmt.Sprintf("Hi %s,\n\nOpen the link below to choose a new password. It expires in %d minutes and can be used once.\n\n%s\n\nIf you did not ask for a password reset, you can ignore this email.", user.FullName, int(passwordResetDuration.Minutes()), resetUrl),
+	})
+	if err != nil {
+		slog.ErrorContext(ctx, "Send password reset failed", "user_id", user.Id, "error", err)
+	}
+}
+
+func (as *authService) ResetPassword(ctx context.Context, req *auth.ResetPasswordRequest) (*auth.ResetPasswordResponse, error) {
+	if req.NewPassword != req.NewPasswordConfirmation {
+		return nil, domainerror.InvalidArgument("PASSWORD_CONFIRMATION_MISMATCH", "New password and new password confirmation do not match").WithField("new_password_confirmation")
+	}
+
+	hashNewPassword, err := bcrypt.GenerateFromPassword([]byte(req.NewPassword), 10)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := as.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err != nil && tx != nil {
+			tx.Rollback()
+		}
+	}()
+
+	oneTimeTokenRepo := as.oneTimeTokenRepository.WithTransaction(tx)
+	authRepo := as.authRepository.WithTransaction(tx)
+
+	now := time.Now()
+	passwordResetToken, err := oneTimeTokenRepo.GetOneTimeTokenByHashForUpdate(ctx, entity.OneTimeTokenPurposePasswordReset, hashOpaqueToken(req.Token))
+	if err != nil {
+		return nil, err
+	}
+	if passwordResetToken == nil || passwordResetToken.UsedAt != nil || !now.Before(passwordResetToken.ExpiresAt) {
+		tx.Rollback()
+		return nil, domainerror.InvalidArgument("INVALID_PASSWORD_RESET_TOKEN", "Invalid or expired password reset token").WithField("token")
+	}
+
+	user, err := authRepo.GetUserById(ctx, passwordResetToken.UserId)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		tx.Rollback()
+		return nil, domainerror.InvalidArgument("INVALID_PASSWORD_RESET_TOKEN", "Invalid or expired password reset token").WithField("token")
+	}
+
+	err = authRepo.UpdateUserPassword(ctx, user.Id, string(hashNewPassword), user.FullName)
+	if err != nil {
+		return nil, err
+	}
+
+	// the redeemed token and every other link still in the user's mailbox stop working
+	err = oneTimeTokenRepo.UseOneTimeTokensByUserId(ctx, entity.OneTimeTokenPurposePasswordReset, user.Id, now)
+	if err != nil {
+		return nil, err
+	}
+
+	// whoever knew the old password is logged out at once, their session is revoked with the refresh tokens
+	err = as.refreshTokenRepository.WithTransaction(tx).RevokeRefreshTokensByUserId(ctx, user.Id, now)
+	if err != nil {
+		return nil, err
+	}
+	err = as.userSessionRepository.WithTransaction(tx).RevokeUserSessionsByUserId(ctx, user.Id, now)
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, err
+	}
+
+	return &auth.ResetPasswordResponse{
+		Base: utils.SuccessResponse("Password reset successfully"),
+	}, nil
+}
+
+func (as *authService) VerifyEmail(ctx context.Context, req *auth.VerifyEmailRequest) (*auth.VerifyEmailResponse, error) {
+
+	tx, err := as.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err != nil && tx != nil {
+			tx.Rollback()
+		}
+	}()
+
+	oneTimeTokenRepo := as.oneTimeTokenRepository.WithTransaction(tx)
+	authRepo := as.authRepository.WithTransaction(tx)
+
+	now := time.Now()
+	emailVerificationToken, err := oneTimeTokenRepo.GetOneTimeTokenByHashForUpdate(ctx, entity.OneTimeTokenPurposeEmailVerification, hashOpaqueToken(req.Token))
+	if err != nil {
+		return nil, err
+	}
+	if emailVerificationToken == nil || emailVerificationToken.UsedAt != nil || !now.Before(emailVerificationToken.ExpiresAt) {
+		tx.Rollback()
+		return nil, domainerror.InvalidArgument("INVALID_VERIFICATION_TOKEN", "Invalid or expired verification token").WithField("token")
+	}
+
+	user, err := authRepo.GetUserById(ctx, emailVerificationToken.UserId)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		tx.Rollback()
+		return nil, domainerror.InvalidArgument("INVALID_VERIFICATION_TOKEN", "Invalid or expired verification token").WithField("token")
+	}
+
+	if user.EmailVerifiedAt == nil {
+		err = authRepo.UpdateUserEmailVerified(ctx, user.Id, now, user.FullName)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err = oneTimeTokenRepo.UseOneTimeTokensByUserId(ctx, entity.OneTimeTokenPurposeEmailVerification, user.Id, now)
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, err
+	}
+
+	return &auth.VerifyEmailResponse{
+		Base: utils.SuccessResponse("Email verified successfully"),
+	}, nil
+}
+
+func (as *authService) ResendVerification(ctx context.Context, req *auth.ResendVerificationRequest) (*auth.ResendVerificationResponse, error) {
+
+	// same answer for unknown and already verified emails, so it cannot be used to find accounts
+	response := &auth.ResendVerificationResponse{
+		Base: utils.SuccessResponse("If the email is registered and not verified yet, a verification link has been sent"),
+	}
+
+	locked, err := as.throttleEmailSend(ctx, emailSendPurposeVerification, req.Email, resendVerificationEmailMaxSends, resendVerificationIpMaxSends, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if locked {
+		return nil, ErrResendVerificationLocked
+	}
+
+	user, err := as.authRepository.GetUserByEmail(ctx, req.Email)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil || user.EmailVerifiedAt != nil {
+		return response, nil
+	}
+
+	err = as.sendEmailVerification(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+func (as *authService) UnlockAccount(ctx context.Context, req *auth.UnlockAccountRequest) (*auth.UnlockAccountResponse, error) {
+
+	err := as.loginAttemptRepository.DeleteLoginAttempt(ctx, loginEmailKey(req.Email))
+	if err != nil {
+		return nil, err
+	}
+
+	return &auth.UnlockAccountResponse{
+		Base: utils.SuccessResponse("Account unlocked successfully"),
+	}, nil
+}
+
+func (as *authService) GetProfile(ctx context.Context, req *auth.GetProfileRequest) (*auth.GetProfileResponse, error) {
+
+	claims, err := jwtentity.GetClaimsFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := as.authRepository.GetUserById(ctx, claims.Subject)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, domainerror.NotFound("USER_NOT_FOUND", "User not found")
+	}
+
+	user, err = as.authRepository.GetUserById(ctx, claims.Subject)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if user == nil {
+		return nil, domainerror.NotFound("USER_NOT_FOUND", "User not found")
+	}
+
+	return &auth.GetProfileResponse{
+		Base:          utils.SuccessResponse("Get Profile Success"),
+		UserId:        user.Id,
+		FullName:      user.FullName,
+		Email:         user.Email,
+		RoleCode:      user.RoleCode,
+		MemberSince:   timestamppb.New(user.CreatedAt),
+		EmailVerified: user.EmailVerifiedAt != nil,
+		PhoneNumber:   stringValue(user.PhoneNumber),
+		Address:       stringValue(user.Address),
+		TotpEnabled:   user.TotpEnabledAt != nil,
+	}, nil
+
+}
+
+func (as *authService) VerifyLoginTotp(ctx context.Context, req *auth.VerifyLoginTotpRequest) (*auth.VerifyLoginTotpResponse, error) {
+
+	now := time.Now()
+	tx, err := as.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err != nil && tx != nil {
+			tx.Rollback()
+		}
+	}()
+
+	loginChallengeRepo := as.loginChallengeRepository.WithTransaction(tx)
+	authRepo := as.authRepository.WithTransaction(tx)
+
+	loginChallenge, err := loginChallengeRepo.GetLoginChallengeByHashForUpdate(ctx, hashOpaqueToken(req.ChallengeToken))
+	if err != nil {
+		return nil, err
+	}
+	if loginChallenge == nil || loginChallenge.UsedAt != nil || !now.Before(loginChallenge.ExpiresAt) || loginChallenge.FailedCount >= loginChallengeMaxFailures {
+		tx.Rollback()
+		return nil, ErrLoginChallengeInvalid
+	}
+
+	user, err := authRepo.GetUserById(ctx, loginChallenge.UserId)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil || user.TotpEnabledAt == nil {
+		tx.Rollback()
+		return nil, ErrLoginChallengeInvalid
+	}
+
+	// wrong codes count as failed logins of the email, so new challenges do not give new guesses
+	emailKey := loginEmailKey(user.Email)
+	locked, err := as.isLoginLocked(ctx, []string{emailKey}, now)
+	if err != nil {
+		return nil, err
+	}
+	if locked {
+		tx.Rollback()
+		return nil, ErrLoginLocked
+	}
+
+	valid, err := verifySecondFactor(ctx, authRepo, as.totpRecoveryCodeRepository.WithTransaction(tx), user, req.Code, now)
+	if err != nil {
+		return nil, err
+	}
+	if !valid {
+		err = loginChallengeRepo.IncrementLoginChallengeFailure(ctx, loginChallenge.Id)
+		if err != nil {
+			return nil, err
+		}
+		err = tx.Commit()
+		if err != nil {
+			return nil, err
+		}
+		err = as.recordLoginFailure(ctx, emailKey, loginEmailMaxFailures, now)
+		if err != nil {
+			return nil, err
+		}
+		return nil, ErrLoginInvalidTotpCode
+	}
+
+	err = loginChallengeRepo.UseLoginChallenge(ctx, loginChallenge.Id, now)
+	if err != nil {
+		return nil, err
+	}
+
+	familyId, refreshToken, err := as.startSession(ctx, tx, user.Id, now)
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, err
+	}
+
+	err = as.loginAttemptRepository.DeleteLoginAttempt(ctx, emailKey)
+	if err != nil {
+		return nil, err
+	}
+
+	accessToken, err := as.signAccessToken(user, familyId, now)
+	if err != nil {
+		return nil, err
+	}
+
+	return &auth.VerifyLoginTotpResponse{
+		Base:         utils.SuccessResponse("Login successful"),
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+	}, nil
+}
+
+func (as *authService) EnrollTotp(ctx context.Context, req *auth.EnrollTotpRequest) (*auth.EnrollTotpResponse, error) {
+
+	claims, err := jwtentity.GetClaimsFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := as.authRepository.GetUserById(ctx, claims.Subject)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, utils.UnaunthorizedResponse()
+	}
+
+	// the password is asked so a stolen access token cannot lock the owner out with an unknown secret
+	err = bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(req.Password))
+	if err != nil {
+		if errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) {
+			return nil, domainerror.InvalidArgument("INCORRECT_PASSWORD", "Password is incorrect").WithField("password")
+		}
+		return nil, err
+	}
+
+	if user.TotpEnabledAt != nil {
+		return nil, domainerror.FailedPrecondition("TOTP_ALREADY_ENABLED", "Two-factor authentication is already enabled")
+	}
+
+	issuer := os.Getenv("TOTP_ISSUER")
+	if issuer == "" {
+		issuer = defaultTotpIssuer
+	}
+	key, err := totp.Generate(totp.GenerateOpts{
+		Issuer:      issuer,
+		AccountName: user.Email,
+		Period:      totpPeriod,
+		Digits:      totpValidateOpts.Digits,
+		Algorithm:   totpValidateOpts.Algorithm,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// a new enrollment replaces a secret that was never confirmed
+	secret := key.Secret()
+	err = as.authRepository.UpdateUserTotpSecret(ctx, user.Id, &secret, user.FullName)
+	if err != nil {
+		return nil, err
+	}
+
+	return &auth.EnrollTotpResponse{
+		Base:       utils.SuccessResponse("Add the secret to your authenticator app, then confirm it with a code"),
+		Secret:     secret,
+		OtpauthUrl: key.URL(),
+	}, nil
+}
+
+func (as *authService) ConfirmTotp(ctx context.Context, req *auth.ConfirmTotpRequest) (*auth.ConfirmTotpResponse, error) {
+
+	claims, err := jwtentity.GetClaimsFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	tx, err := as.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err != nil && tx != nil {
+			tx.Rollback()
+		}
+	}()
+
+	authRepo := as.authRepository.WithTransaction(tx)
+	totpRecoveryCodeRepo := as.totpRecoveryCodeRepository.WithTransaction(tx)
+
+	user, err := authRepo.GetUserById(ctx, claims.Subject)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		tx.Rollback()
+		return nil, utils.UnaunthorizedResponse()
+	}
+	if user.TotpEnabledAt != nil {
+		tx.Rollback()
+		return nil, domainerror.FailedPrecondition("TOTP_ALREADY_ENABLED", "Two-factor authentication is already enabled")
+	}
+	if user.TotpSecret == nil {
+		tx.Rollback()
+		return nil, domainerror.FailedPrecondition("TOTP_NOT_ENROLLED", "Start the enrollment with EnrollTotp first")
+	}
+
+	valid, err := verifyTotpCode(ctx, authRepo, user, req.Code, now)
+	if err != nil {
+		return nil, err
+	}
+	if !valid {
+		tx.Rollback()
+		return nil, domainerror.InvalidArgument("INVALID_TOTP_CODE", "Invalid two-factor code").WithField("code")
+	}
+
+	err = authRepo.EnableUserTotp(ctx, user.Id, now, user.FullName)
+	if err != nil {
+		return nil, err
+	}
+
+	err = totpRecoveryCodeRepo.DeleteTotpRecoveryCodesByUserId(ctx, user.Id)
+	if err != nil {
+		return nil, err
+	}
+	recoveryCodes, err := generateRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+	for _, recoveryCode := range recoveryCodes {
+		err = totpRecoveryCodeRepo.CreateTotpRecoveryCode(ctx, &entity.TotpRecoveryCode{
+			Id:        uuid.New().String(),
+			UserId:    user.Id,
+			CodeHash:  hashOpaqueToken(normalizeRecoveryCode(recoveryCode)),
+			CreatedAt: now,
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// other logins were made with the password alone, they have to log in again with a code
+	err = as.refreshTokenRepository.WithTransaction(tx).RevokeOtherRefreshTokenFamilies(ctx, user.Id, claims.SessionId, now)
+	if err != nil {
+		return nil, err
+	}
+	err = as.userSessionRepository.WithTransaction(tx).RevokeOtherUserSessions(ctx, user.Id, claims.SessionId, now)
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, err
+	}
+
+	return &auth.ConfirmTotpResponse{
+		Base:          utils.SuccessResponse("Two-factor authentication enabled, store the recovery codes somewhere safe"),
+		RecoveryCodes: recoveryCodes,
+	}, nil
+}
+
+func (as *authService) DisableTotp(ctx context.Context, req *auth.DisableTotpRequest) (*auth.DisableTotpResponse, error) {
+
+	claims, err := jwtentity.GetClaimsFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	tx, err := as.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err != nil && tx != nil {
+			tx.Rollback()
+		}
+	}()
+
+	authRepo := as.authRepository.WithTransaction(tx)
+	totpRecoveryCodeRepo := as.totpRecoveryCodeRepository.WithTransaction(tx)
+
+	user, err := authRepo.GetUserById(ctx, claims.Subject)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		tx.Rollback()
+		return nil, utils.UnaunthorizedResponse()
+	}
+	if user.TotpEnabledAt == nil {
+		tx.Rollback()
+		return nil, domainerror.FailedPrecondition("TOTP_NOT_ENABLED", "Two-factor authentication is not enabled")
+	}
+
+	err = bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(req.Password))
+	if err != nil {
+		if errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) {
+			tx.Rollback()
+			return nil, domainerror.InvalidArgument("INCORRECT_PASSWORD", "Password is incorrect").WithField("password")
+		}
+		return nil, err
+	}
+
+	valid, err := verifySecondFactor(ctx, authRepo, totpRecoveryCodeRepo, user, req.Code, now)
+	if err != nil {
+		return nil, err
+	}
+	if !valid {
+		tx.Rollback()
+		return nil, domainerror.InvalidArgument("INVALID_TOTP_CODE", "Invalid two-factor code").WithField("code")
+	}
+
+	err = authRepo.UpdateUserTotpSecret(ctx, user.Id, nil, user.FullName)
+	if err != nil {
+		return nil, err
+	}
+
+	err = totpRecoveryCodeRepo.DeleteTotpRecoveryCodesByUserId(ctx, user.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, err
+	}
+
+	return &auth.DisableTotpResponse{
+		Base: utils.SuccessResponse("Two-factor authentication disabled"),
+	}, nil
+}
+
+func (as *authService) StartOidcLogin(ctx context.Context, req *auth.StartOidcLoginRequest) (*auth.StartOidcLoginResponse, error) {
+
+	provider, ok := as.oidcProviders[req.Provider]
+	if !ok {
+		return nil, domainerror.InvalidArgument("UNKNOWN_LOGIN_PROVIDER", "Unknown login provider").WithField("provider")
+	}
+
+	state, err := generateOpaqueToken()
+	if err != nil {
+		return nil, err
+	}
+	nonce, err := generateOpaqueToken()
+	if err != nil {
+		return nil, err
+	}
+	// 43 url safe characters are a valid PKCE code verifier
+	codeVerifier, err := generateOpaqueToken()
+	if err != nil {
+		return nil, err
+	}
+
+	authorizationUrl, err := provider.AuthCodeUrl(ctx, state, nonce, codeVerifier)
+	if err != nil {
+		slog.WarnContext(ctx, "Oidc provider unavailable", "provider", provider.Name(), "error", err)
+		return nil, ErrOidcProviderUnavailable
+	}
+
+	now := time.Now()
+	err = as.oidcLoginStateRepository.CreateOidcLoginState(ctx, &entity.OidcLoginState{
+		Id:           uuid.New().String(),
+		StateHash:    hashOpaqueToken(state),
+		Provider:     provider.Name(),
+		Nonce:        nonce,
+		CodeVerifier: codeVerifier,
+		ExpiresAt:    now.Add(oidcLoginStateDuration),
+		CreatedAt:    now,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &auth.StartOidcLoginResponse{
+		Base:             utils.SuccessResponse("Continue the login at the identity provider"),
+		AuthorizationUrl: authorizationUrl,
+	}, nil
+}
+
+func (as *authService) OidcLogin(ctx context.Context, req *auth.OidcLoginRequest) (*auth.OidcLoginResponse, error) {
+
+	provider, ok := as.oidcProviders[req.Provider]
+	if !ok {
+		return nil, domainerror.InvalidArgument("UNKNOWN_LOGIN_PROVIDER", "Unknown login provider").WithField("provider")
+	}
+
+	now := time.Now()
+	tx, err := as.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err != nil && tx != nil {
+			tx.Rollback()
+		}
+	}()
+
+	oidcLoginStateRepo := as.oidcLoginStateRepository.WithTransaction(tx)
+	oidcLoginState, err := oidcLoginStateRepo.GetOidcLoginStateByHashForUpdate(ctx, hashOpaqueToken(req.State))
+	if err != nil {
+		return nil, err
+	}
+	if oidcLoginState == nil || oidcLoginState.UsedAt != nil || !now.Before(oidcLoginState.ExpiresAt) || oidcLoginState.Provider != provider.Name() {
+		tx.Rollback()
+		return nil, domainerror.InvalidArgument("INVALID_LOGIN_STATE", "Invalid or expired login state, start the login again").WithField("state")
+	}
+
+	// the state is spent before the code is redeemed, so a failed exchange cannot be retried with it
+	err = oidcLoginStateRepo.UseOidcLoginState(ctx, oidcLoginState.Id, now)
+	if err != nil {
+		return nil, err
+	}
+	err = tx.Commit()
+	if err != nil {
+		return nil, err
+	}
+
+	identity, err := provider.Exchange(ctx, req.Code, oidcLoginState.Nonce, oidcLoginState.CodeVerifier)
+	if err != nil {
+		slog.WarnContext(ctx, "Oidc login failed", "provider", provider.Name(), "error", err)
+		return nil, ErrOidcLoginFailed
+	}
+
+	user, err := as.findOrCreateOidcUser(ctx, provider, identity, now)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, ErrOidcLoginFailed
+	}
+
+	loginResponse, err := as.completeLogin(ctx, user, now)
+	if err != nil {
+		return nil, err
+	}
+
+	return &auth.OidcLoginResponse{
+		Base:                   loginResponse.Base,
+		AccessToken:            loginResponse.AccessToken,
+		RefreshToken:           loginResponse.RefreshToken,
+		TotpRequired:           loginResponse.TotpRequired,
+		ChallengeToken:         loginResponse.ChallengeToken,
+		TotpEnrollmentRequired: loginResponse.TotpEnrollmentRequired,
+	}, nil
+}
+
+// completeLogin is the end of every login once the user is known: a challenge for two-factor accounts, tokens otherwise.
+func (as *authService) completeLogin(ctx context.Context, user *entity.User, now time.Time) (*auth.LoginResponse, error) {
+	// the email counter is only cleared once every factor passed, VerifyLoginTotp clears it for two-factor accounts,
+	// otherwise a known password would reset the lockout of the code guesses
+	if user.TotpEnabledAt != nil {
+		challengeToken, err := as.createLoginChallenge(ctx, user.Id, now)
+		if err != nil {
+			return nil, err
+		}
+		return &auth.LoginResponse{
+			Base:           utils.SuccessResponse("Enter the code of your authenticator app"),
+			TotpRequired:   true,
+			ChallengeToken: challengeToken,
+		}, nil
+	}
+
+	// the IP counter is kept, otherwise one valid account would reset it for a spraying client
+	err := as.loginAttemptRepository.DeleteLoginAttempt(ctx, loginEmailKey(user.Email))
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := as.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err != nil && tx != nil {
+			tx.Rollback()
+		}
+	}()
+
+	familyId, refreshToken, err := as.startSession(ctx, tx, user.Id, now)
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, err
+	}
+
+	accessToken, err := as.signAccessToken(user, familyId, now)
+	if err != nil {
+		return nil, err
+	}
+
+	return &auth.LoginResponse{
+		Base:                   utils.SuccessResponse("Login successful"),
+		AccessToken:            accessToken,
+		RefreshToken:           refreshToken,
+		TotpEnrollmentRequired: as.totpPolicy.RequiredFor(user.RoleCode),
+	}, nil
+}
+
+// createLoginChallenge stores the hash of a challenge token that VerifyLoginTotp exchanges for tokens.
+func (as *authService) createLoginChallenge(ctx context.Context, userId string, now time.Time) (string, error) {
+	rawToken, err := generateOpaqueToken()
+	if err != nil {
+		return "", err
+	}
+
+	err = as.loginChallengeRepository.CreateLoginChallenge(ctx, &entity.LoginChallenge{
+		Id:        uuid.New().String(),
+		UserId:    userId,
+		TokenHash: hashOpaqueToken(rawToken),
+		ExpiresAt: now.Add(loginChallengeDuration),
+		CreatedAt: now,
+	})
+	if err != nil {
+		return "", err
+	}
+	return rawToken, nil
+}
+
+func (as *authService) UpdateProfile(ctx context.Context, req *auth.UpdateProfileRequest) (*auth.UpdateProfileResponse, error) {
+
+	claims, err := jwtentity.GetClaimsFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	err = as.authRepository.UpdateUserProfile(ctx, claims.Subject, req.FullName, nullableString(req.PhoneNumber), nullableString(req.Address), req.FullName)
+	if err != nil {
+		return nil, err
+	}
+
+	accessToken, err := as.reissueAccessToken(ctx, claims)
+	if err != nil {
+		return nil, err
+	}
+
+	return &auth.UpdateProfileResponse{
+		Base:        utils.SuccessResponse("Profile updated successfully"),
+		AccessToken: accessToken,
+	}, nil
+}
+
+func (as *authService) RequestEmailChange(ctx context.Context, req *auth.RequestEmailChangeRequest) (*auth.RequestEmailChangeResponse, error) {
+
+	claims, err := jwtentity.GetClaimsFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := as.authRepository.GetUserById(ctx, claims.Subject)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, utils.UnaunthorizedResponse()
+	}
+
+	// a stolen access token alone must not be enough to move the account to another address
+	err = bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(req.Password))
+	if err != nil {
+		if errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) {
+			return nil, domainerror.InvalidArgument("INCORRECT_PASSWORD", "Password is incorrect").WithField("password")
+		}
+		return nil, err
+	}
+
+	if req.NewEmail == user.Email {
+		return nil, domainerror.InvalidArgument("SAME_EMAIL", "New email is the same as the current email").WithField("new_email")
+	}
+
+	registered, err := as.authRepository.IsEmailRegistered(ctx, req.NewEmail)
+	if err != nil {
+		return nil, err
+	}
+	if registered {
+		return nil, domainerror.Conflict("EMAIL_ALREADY_USED", "Email is already used by another account")
+	}
+
+	rawToken, err := generateOpaqueToken()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	err = as.oneTimeTokenRepository.CreateOneTimeToken(ctx, &entity.OneTimeToken{
+		Id:        uuid.New().String(),
+		UserId:    user.Id,
+		Purpose:   entity.OneTimeTokenPurposeEmailChange,
+		NewEmail:  &req.NewEmail,
+		TokenHash: hashOpaqueToken(rawToken),
+		ExpiresAt: now.Add(emailChangeDuration),
+		CreatedAt: now,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	confirmUrl := fmt.Sprintf("%s/confirm-email-change?token=%s", os.Getenv("FE_BASE_URL"), url.QueryEscape(rawToken))
+	err = as.mailer.Send(ctx, &mailer.Message{
+		To:      req.NewEmail,
+		Subject: "Confirm your new email",
+		Body:    fmt.Sprintf("Hi %s,\n\nOpen the link below while logged in to use this address for your account. It expires in %d minutes.\n\n%s\n\nIf you did not ask for this change, you can ignore this email.", user.FullName, int(emailChangeDuration.Minutes()), confirmUrl),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// the current address is told as well, so an unexpected change does not go unnoticed
+	err = as.mailer.Send(ctx, &mailer.Message{
+		To:      user.Email,
+		Subject: "Email change requested",
+		Body:    fmt.Sprintf("Hi %s,\n\nA change of your account email to %s was requested. It only takes effect once the new address is confirmed. If this was not you, change your password.", user.FullName, req.NewEmail),
+	})
+	if err != nil {
+		slog.ErrorContext(ctx, "Send email change notice failed", "user_id", user.Id, "error", err)
+	}
+
+	return &auth.RequestEmailChangeResponse{
+		Base: utils.SuccessResponse("A confirmation link has been sent to the new email"),
+	}, nil
+}
+
+func (as *authService) ConfirmEmailChange(ctx context.Context, req *auth.ConfirmEmailChangeRequest) (*auth.ConfirmEmailChangeResponse, error) {
+
+	claims, err := jwtentity.GetClaimsFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := as.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err != nil && tx != nil {
+			tx.Rollback()
+		}
+	}()
+
+	oneTimeTokenRepo := as.oneTimeTokenRepository.WithTransaction(tx)
+	authRepo := as.authRepository.WithTransaction(tx)
+
+	now := time.Now()
+	emailChangeToken, err := oneTimeTokenRepo.GetOneTimeTokenByHashForUpdate(ctx, entity.OneTimeTokenPurposeEmailChange, hashOpaqueToken(req.Token))
+	if err != nil {
+		return nil, err
+	}
+	// the link only works for the account that asked for the change
+	if emailChangeToken == nil || emailChangeToken.NewEmail == nil || emailChangeToken.UserId != claims.Subject || emailChangeToken.UsedAt != nil || !now.Before(emailChangeToken.ExpiresAt) {
+		tx.Rollback()
+		return nil, domainerror.InvalidArgument("INVALID_EMAIL_CHANGE_TOKEN", "Invalid or expired email change token").WithField("token")
+	}
+
+	// the address may have been registered since the change was requested
+	registered, err := authRepo.IsEmailRegistered(ctx, *emailChangeToken.NewEmail)
+	if err != nil {
+		return nil, err
+	}
+	if registered {
+		tx.Rollback()
+		return nil, domainerror.Conflict("EMAIL_ALREADY_USED", "Email is already used by another account")
+	}
+
+	err = authRepo.UpdateUserEmail(ctx, claims.Subject, *emailChangeToken.NewEmail, now, claims.FullName)
+	if err != nil {
+		return nil, err
+	}
+
+	// links mailed to the old address stop working too, whoever still reads that mailbox cannot reset the password
+	for _, purpose := range []string{entity.OneTimeTokenPurposeEmailChange, entity.OneTimeTokenPurposePasswordReset, entity.OneTimeTokenPurposeEmailVerification} {
+		err = oneTimeTokenRepo.UseOneTimeTokensByUserId(ctx, purpose, claims.Subject, now)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, err
+	}
+
+	accessToken, err := as.reissueAccessToken(ctx, claims)
+	if err != nil {
+		return nil, err
+	}
+
+	return &auth.ConfirmEmailChangeResponse{
+		Base:        utils.SuccessResponse("Email changed successfully"),
+		AccessToken: accessToken,
+	}, nil
+}
+
+// reissueAccessToken signs a token with the stored profile for the same session and revokes the calling token,
+// so clients reading the claims do not keep a stale name or email.
+func (as *authService) reissueAccessToken(ctx context.Context, claims *jwtentity.JwtClaims) (string, error) {
+	user, err := as.authRepository.GetUserById(ctx, claims.Subject)
+	if err != nil {
+		return "", err
+	}
+	if user == nil {
+		return "", utils.UnaunthorizedResponse()
+	}
+
+	accessToken, err := as.signAccessToken(user, claims.SessionId, time.Now())
+	if err != nil {
+		return "", err
+	}
+
+	err = as.tokenRevocationStore.Revoke(ctx, claims.ID, claims.ExpiresAt.Time)
+	if err != nil {
+		return "", err
+	}
+	return accessToken, nil
+}
+
+func stringValue(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
+}
+
+// nullableString stores an empty optional profile field as NULL
+func nullableString(value string) *string {
+	if value == "" {
+		return nil
+	}
+	return &value
+}
+
+func (as *authService) signAccessToken(user *entity.User, sessionId string, now time.Time) (string, error) {
+	return as.keySet.SignClaims(&jwtentity.JwtClaims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        uuid.New().String(),
+			Subject:   user.Id,
+			ExpiresAt: jwt.NewNumericDate(now.Add(accessTokenDuration)),
+			IssuedAt:  jwt.NewNumericDate(now),
+		},
+		Email:         user.Email,
+		FullName:      user.FullName,
+		Role:          user.RoleCode,
+		SessionId:     sessionId,
+		EmailVerified: user.EmailVerifiedAt != nil,
+	})
+}
+
+// sendEmailVerification stores a new verification token for the user and emails the link to it.
+// Earlier links keep working until they expire or one of them is used.
+func (as *authService) sendEmailVerification(ctx context.Context, user *entity.User) error {
+	rawToken, err := generateOpaqueToken()
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	err = as.oneTimeTokenRepository.CreateOneTimeToken(ctx, &entity.OneTimeToken{
+		Id:        uuid.New().String(),
+		UserId:    user.Id,
+		Purpose:   entity.OneTimeTokenPurposeEmailVerification,
+		TokenHash: hashOpaqueToken(rawToken),
+		ExpiresAt: now.Add(emailVerificationDuration),
+		CreatedAt: now,
+	})
+	if err != nil {
+		return err
+	}
+
+	verifyUrl := fmt.Sprintf("%s/verify-email?token=%s", os.Getenv("FE_BASE_URL"), url.QueryEscape(rawToken))
+	return as.mailer.Send(ctx, &mailer.Message{
+		To:      user.Email,
+		Subject: "Verify your email",
+		Body:    fmt.Sprintf("Hi %s,\n\nOpen the link below to verify your email. It expires in %d hours.\n\n%s\n\nIf you did not create an account, you can ignore this email.", user.FullName, int(emailVerificationDuration.Hours()), verifyUrl),
+	})
+}
+
+// startSession records the session of a new login and issues the first refresh token of its family,
+// the session id is the family id and the sid claim of the access tokens.
+func (as *authService) startSession(ctx context.Context, tx *sql.Tx, userId string, now time.Time) (string, string, error) {
+	sessionId := uuid.New().String()
+	refreshTokenEntity, refreshToken, err := as.issueRefreshToken(ctx, as.refreshTokenRepository.WithTransaction(tx), userId, sessionId, now)
+	if err != nil {
+		return "", "", err
+	}
+
+	err = as.createUserSession(ctx, as.userSessionRepository.WithTransaction(tx), sessionId, userId, refreshTokenEntity.ExpiresAt, now)
+	if err != nil {
+		return "", "", err
+	}
+	return sessionId, refreshToken, nil
+}
+
+func (as *authService) createUserSession(ctx context.Context, userSessionRepo repository.IUserSessionRepository, sessionId string, userId string, expiresAt time.Time, now time.Time) error {
+	return userSessionRepo.CreateUserSession(ctx, &entity.UserSession{
+		Id:         sessionId,
+		UserId:     userId,
+		UserAgent:  utils.UserAgentFromContext(ctx),
+		IpAddress:  utils.ClientIpFromContext(ctx),
+		CreatedAt:  now,
+		LastSeenAt: now,
+		ExpiresAt:  expiresAt,
+	})
+}
+
+// revokeSession ends a session, its access tokens are rejected by the auth middleware and its refresh tokens revoked.
+func (as *authService) revokeSession(ctx context.Context, sessionId string, now time.Time) error {
+	tx, err := as.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil && tx != nil {
+			tx.Rollback()
+		}
+	}()
+
+	err = as.refreshTokenRepository.WithTransaction(tx).RevokeRefreshTokenFamily(ctx, sessionId, now)
+	if err != nil {
+		return err
+	}
+	err = as.userSessionRepository.WithTransaction(tx).RevokeUserSession(ctx, sessionId, now)
+	if err != nil {
+		return err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// issueRefreshToken stores the hash of a new random token in the family and returns the raw token for the client.
+// When it rotates an existing token, the previous one is expected to be marked as used by the caller.
+func (as *authService) issueRefreshToken(ctx context.Context, refreshTokenRepo repository.IRefreshTokenRepository, userId string, familyId string, now time.Time) (*entity.RefreshToken, string, error) {
+	rawToken, err := generateOpaqueToken()
+	if err != nil {
+		return nil, "", err
+	}
+
+	refreshTokenEntity := &entity.RefreshToken{
+		Id:        uuid.New().String(),
+		UserId:    userId,
+		FamilyId:  familyId,
+		TokenHash: hashOpaqueToken(rawToken),
+		ExpiresAt: now.Add(refreshTokenDuration),
+		CreatedAt: now,
+	}
+	err = refreshTokenRepo.CreateRefreshToken(ctx, refreshTokenEntity)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return refreshTokenEntity, rawToken, nil
+}
+
+// generateOpaqueToken returns a random token for refresh and one-time tokens, only its hash is stored.
+func generateOpaqueToken() (string, error) {
+	tokenBytes := make([]byte, 32)
+	_, err := rand.Read(tokenBytes)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(tokenBytes), nil
+}
+
+func hashOpaqueToken(rawToken string) string {
+	hash := sha256.Sum256([]byte(rawToken))
+	return hex.EncodeToString(hash[:])
+}
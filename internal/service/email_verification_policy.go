@@ -0,0 +1,38 @@
+package service
+
+import (
+	"fmt"
+	"os"
+)
+
+// EmailVerificationPolicy decides what an account can do before its email is verified.
+type EmailVerificationPolicy string
+
+const (
+	// EmailVerificationPolicyNone lets unverified accounts do everything
+	EmailVerificationPolicyNone EmailVerificationPolicy = "none"
+	// EmailVerificationPolicyOrder lets unverified accounts log in but not create orders
+	EmailVerificationPolicyOrder EmailVerificationPolicy = "order"
+	// EmailVerificationPolicyLogin refuses to log unverified accounts in
+	EmailVerificationPolicyLogin EmailVerificationPolicy = "login"
+)
+
+// NewEmailVerificationPolicyFromEnv reads EMAIL_VERIFICATION_POLICY, blocking nothing when it is not set,
+// so accounts created before email verification existed keep working until the policy is turned on.
+func NewEmailVerificationPolicyFromEnv() (EmailVerificationPolicy, error) {
+	switch policy := EmailVerificationPolicy(os.Getenv("EMAIL_VERIFICATION_POLICY")); policy {
+	case "":
+		return EmailVerificationPolicyNone, nil
+	case EmailVerificationPolicyNone, EmailVerificationPolicyOrder, EmailVerificationPolicyLogin:
+		return policy, nil
+	}
+	return "", fmt.Errorf("unknown email verification policy %q", os.Getenv("EMAIL_VERIFICATION_POLICY"))
+}
+
+func (p EmailVerificationPolicy) BlocksLogin() bool {
+	return p == EmailVerificationPolicyLogin
+}
+
+func (p EmailVerificationPolicy) BlocksOrder() bool {
+	return p != EmailVerificationPolicyNone
+}
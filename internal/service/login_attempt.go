@@ -0,0 +1,136 @@
+package service
+
+import (
+	"context"
+	"math"
+	"strings"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/arthurhzna/Golang_gRPC/internal/utils"
+)
+
+const (
+	// failures allowed before a key is locked, an IP is shared by more users than an email
+	loginEmailMaxFailures = 5
+	loginIpMaxFailures    = 20
+	// failures older than loginAttemptWindow are forgotten, it must be longer than loginMaxLockDuration
+	loginAttemptWindow    = time.Hour
+	loginBaseLockDuration = time.Minute
+	loginMaxLockDuration  = time.Minute * 15
+	// LoginAttemptRetention is how long a counter is kept after its last failure, a later failure would start again at 1
+	LoginAttemptRetention = loginAttemptWindow
+
+	// verification emails allowed per window before ResendVerification is locked like a login
+	resendVerificationEmailMaxSends = 3
+	resendVerificationIpMaxSends    = 10
+	// reset emails allowed per window before RequestPasswordReset is locked like a login
+	passwordResetEmailMaxSends = 3
+	passwordResetIpMaxSends    = 10
+
+	emailSendPurposeVerification  = "verification"
+	emailSendPurposePasswordReset = "password_reset"
+)
+
+var (
+	ErrLoginInvalidCredentials  = status.Errorf(codes.Unauthenticated, "Invalid email or password")
+	ErrLoginLocked              = status.Errorf(codes.ResourceExhausted, "Too many failed login attempts, try again later")
+	ErrResendVerificationLocked = status.Errorf(codes.ResourceExhausted, "Too many verification emails requested, try again later")
+	ErrPasswordResetLocked      = status.Errorf(codes.ResourceExhausted, "Too many password reset emails requested, try again later")
+)
+
+// dummyPasswordHash is compared when the email is unknown, so both cases take the time of a bcrypt check.
+var dummyPasswordHash, _ = bcrypt.GenerateFromPassword([]byte("dummy password for unknown users"), 10)
+
+// Emails are tracked whether they belong to a user or not, so a lockout does not reveal registered emails.
+func loginEmailKey(email string) string {
+	return "email:" + strings.ToLower(strings.TrimSpace(email))
+}
+
+func loginIpKey(clientIp string) string {
+	return "ip:" + clientIp
+}
+
+// Requests sending an email are counted apart from logins, under keys prefixed with their purpose that never collide with the login keys.
+func emailSendEmailKey(purpose string, email string) string {
+	return purpose + ":" + loginEmailKey(email)
+}
+
+func emailSendIpKey(purpose string, clientIp string) string {
+	return purpose + ":" + loginIpKey(clientIp)
+}
+
+// loginLockDuration doubles the lock for every failure past maxFailures, up to loginMaxLockDuration.
+func loginLockDuration(failedCount int, maxFailures int) time.Duration {
+	if failedCount < maxFailures {
+		return 0
+	}
+	exponent := failedCount - maxFailures
+	if exponent > 10 {
+		return loginMaxLockDuration
+	}
+	lockDuration := loginBaseLockDuration * time.Duration(math.Pow(2, float64(exponent)))
+	if lockDuration > loginMaxLockDuration {
+		return loginMaxLockDuration
+	}
+	return lockDuration
+}
+
+func (as *authService) isLoginLocked(ctx context.Context, keys []string, now time.Time) (bool, error) {
+	loginAttempts, err := as.loginAttemptRepository.GetLoginAttemptsByKeys(ctx, keys)
+	if err != nil {
+		return false, err
+	}
+	for _, loginAttempt := range loginAttempts {
+		if loginAttempt.LockedUntil != nil && now.Before(*loginAttempt.LockedUntil) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (as *authService) recordLoginFailure(ctx context.Context, key string, maxFailures int, now time.Time) error {
+	failedCount, err := as.loginAttemptRepository.IncrementLoginFailure(ctx, key, now, now.Add(-loginAttemptWindow))
+	if err != nil {
+		return err
+	}
+
+	lockDuration := loginLockDuration(failedCount, maxFailures)
+	if lockDuration == 0 {
+		return nil
+	}
+	return as.loginAttemptRepository.LockLoginAttempt(ctx, key, now.Add(lockDuration))
+}
+
+// throttleEmailSend counts a request that may send an email of the purpose against the email and the client IP,
+// whether the email is registered or not, so the endpoint cannot be used to flood a mailbox. It reports whether either
+// key is locked, a locked request is not counted.
+func (as *authService) throttleEmailSend(ctx context.Context, purpose string, email string, emailMaxSends int, ipMaxSends int, now time.Time) (bool, error) {
+	emailKey := emailSendEmailKey(purpose, email)
+	throttleKeys := []string{emailKey}
+	ipKey := ""
+	clientIp := utils.ClientIpFromContext(ctx)
+	if clientIp != "" {
+		ipKey = emailSendIpKey(purpose, clientIp)
+		throttleKeys = append(throttleKeys, ipKey)
+	}
+	locked, err := as.isLoginLocked(ctx, throttleKeys, now)
+	if err != nil || locked {
+		return locked, err
+	}
+
+	err = as.recordLoginFailure(ctx, emailKey, emailMaxSends, now)
+	if err != nil {
+		return false, err
+	}
+	if ipKey != "" {
+		err = as.recordLoginFailure(ctx, ipKey, ipMaxSends, now)
+		if err != nil {
+			return false, err
+		}
+	}
+	return false, nil
+}
@@ -0,0 +1,140 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"golang.org/x/crypto/bcrypt"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/arthurhzna/Golang_gRPC/internal/entity"
+	"github.com/arthurhzna/Golang_gRPC/internal/oidc"
+)
+
+// oidcLoginStateDuration is how long the user has to sign in at the provider
+const oidcLoginStateDuration = time.Minute * 10
+
+var (
+	ErrOidcLoginFailed           = status.Errorf(codes.Unauthenticated, "Login with the identity provider failed")
+	ErrOidcLoginEmailNotVerified = status.Errorf(codes.Unauthenticated, "The identity provider did not confirm an email address")
+	ErrOidcLoginAccountExists    = status.Errorf(codes.FailedPrecondition, "An account with this email already exists, sign in with your password")
+	ErrOidcProviderUnavailable   = status.Errorf(codes.Unavailable, "The identity provider is not reachable, try again later")
+)
+
+// canLinkOidcIdentity tells whether an identity seen for the first time may attach to the existing account with its
+// email. The provider has to opt in, and accounts an email takeover would hurt most are never linked automatically.
+func canLinkOidcIdentity(provider oidc.Provider, user *entity.User) bool {
+	return provider.LinkExistingAccounts() && user.RoleCode == entity.UserRoleCustomer && user.TotpEnabledAt == nil
+}
+
+// findOrCreateOidcUser returns the user linked to the identity. An identity seen for the first time is linked to the
+// account with its email when canLinkOidcIdentity allows it, or a new customer account is created; either way the
+// email counts as verified. It returns nil when the account is disabled.
+func (as *authService) findOrCreateOidcUser(ctx context.Context, provider oidc.Provider, identity *oidc.Identity, now time.Time) (*entity.User, error) {
+	tx, err := as.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err != nil && tx != nil {
+			tx.Rollback()
+		}
+	}()
+
+	authRepo := as.authRepository.WithTransaction(tx)
+	userIdentityRepo := as.userIdentityRepository.WithTransaction(tx)
+
+	userIdentity, err := userIdentityRepo.GetUserIdentity(ctx, provider.Name(), identity.Subject)
+	if err != nil {
+		return nil, err
+	}
+	if userIdentity != nil {
+		tx.Rollback()
+		return as.authRepository.GetUserById(ctx, userIdentity.UserId)
+	}
+
+	// linking by email is only safe when the provider vouches for the address
+	if identity.Email == "" || !identity.EmailVerified {
+		tx.Rollback()
+		return nil, ErrOidcLoginEmailNotVerified
+	}
+
+	user, err := authRepo.GetUserByEmail(ctx, identity.Email)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		var registered bool
+		registered, err = authRepo.IsEmailRegistered(ctx, identity.Email)
+		if err != nil {
+			return nil, err
+		}
+		if registered {
+			tx.Rollback()
+			return nil, nil
+		}
+
+		// the account has no usable password until the user sets one with RequestPasswordReset
+		var unusablePassword string
+		unusablePassword, err = generateOpaqueToken()
+		if err != nil {
+			return nil, err
+		}
+		var hashPassword []byte
+		hashPassword, err = bcrypt.GenerateFromPassword([]byte(unusablePassword), 10)
+		if err != nil {
+			return nil, err
+		}
+
+		fullName := identity.Name
+		if fullName == "" {
+			fullName = identity.Email
+		}
+		user = &entity.User{
+			Id:        uuid.New().String(),
+			FullName:  fullName,
+			Email:     identity.Email,
+			Password:  string(hashPassword),
+			RoleCode:  entity.UserRoleCustomer,
+			CreatedAt: now,
+			CreatedBy: &fullName,
+		}
+		err = authRepo.InsertUser(ctx, user)
+		if err != nil {
+			return nil, err
+		}
+	} else if !canLinkOidcIdentity(provider, user) {
+		tx.Rollback()
+		slog.WarnContext(ctx, "Oidc identity not linked to existing account", "provider", provider.Name(), "user_id", user.Id)
+		return nil, ErrOidcLoginAccountExists
+	}
+
+	if user.EmailVerifiedAt == nil {
+		err = authRepo.UpdateUserEmailVerified(ctx, user.Id, now, user.FullName)
+		if err != nil {
+			return nil, err
+		}
+		user.EmailVerifiedAt = &now
+	}
+
+	err = userIdentityRepo.CreateUserIdentity(ctx, &entity.UserIdentity{
+		Id:        uuid.New().String(),
+		UserId:    user.Id,
+		Provider:  provider.Name(),
+		Subject:   identity.Subject,
+		Email:     identity.Email,
+		CreatedAt: now,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, err
+	}
+	return user, nil
+}
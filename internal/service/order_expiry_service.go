@@ -0,0 +1,150 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+	"time"
+
+	"github.com/arthurhzna/Golang_gRPC/internal/entity"
+	"github.com/arthurhzna/Golang_gRPC/internal/payment"
+	"github.com/arthurhzna/Golang_gRPC/internal/pubsub"
+	"github.com/arthurhzna/Golang_gRPC/internal/repository"
+)
+
+const orderExpiryBatchSize = 50
+
+type IOrderExpiryService interface {
+	ExpireUnpaidOrders(ctx context.Context) (int, error)
+}
+
+type orderExpiryService struct {
+	db                *sql.DB
+	orderRepository   repository.IOrderRepository
+	productRepository repository.IProductRepository
+	paymentGateway    payment.PaymentGateway
+	orderEventBus     pubsub.OrderEventBus
+}
+
+func NewOrderExpiryService(db *sql.DB, orderRepository repository.IOrderRepository, productRepository repository.IProductRepository, paymentGateway payment.PaymentGateway, orderEventBus pubsub.OrderEventBus) IOrderExpiryService {
+	return &orderExpiryService{
+		db:                db,
+		orderRepository:   orderRepository,
+		productRepository: productRepository,
+		paymentGateway:    paymentGateway,
+		orderEventBus:     orderEventBus,
+	}
+}
+
+// ExpireUnpaidOrders moves the unpaid orders past their expiry time to expired, batch by batch,
+// expires their payment invoice and gives the reserved stock back. It returns how many orders were expired.
+// Invoices are expired before any order row is locked, so a slow payment provider never holds locks on orders.
+func (oes *orderExpiryService) ExpireUnpaidOrders(ctx context.Context) (int, error) {
+	now := time.Now()
+	// skippedIds are the orders whose invoice could not be expired, they are left out of the next batches of this run
+	// and tried again on the next run
+	skippedIds := make([]string, 0)
+	expiredCount := 0
+	for {
+		orderIds, err := oes.orderRepository.GetExpiredUnpaidOrderIds(ctx, now, skippedIds, orderExpiryBatchSize)
+		if err != nil {
+			return expiredCount, err
+		}
+		if len(orderIds) == 0 {
+			return expiredCount, nil
+		}
+
+		for _, orderId := range orderIds {
+			orderEntity, err := oes.orderRepository.GetOrderById(ctx, orderId)
+			if err != nil {
+				return expiredCount, err
+			}
+			if orderEntity == nil {
+				continue
+			}
+
+			if orderEntity.PaymentInvoiceId != nil && !expirePaymentInvoice(ctx, oes.paymentGateway, *orderEntity.PaymentInvoiceId) {
+				skippedIds = append(skippedIds, orderId)
+				continue
+			}
+
+			expiredOrder, err := oes.expireOrder(ctx, orderId, now)
+			if err != nil {
+				return expiredCount, err
+			}
+			if expiredOrder == nil {
+				continue
+			}
+			publishOrderStatusEvent(ctx, oes.orderEventBus, expiredOrder)
+			expiredCount++
+		}
+	}
+}
+
+// expireOrder locks the order and expires it with its stock given back, unless it is no longer unpaid,
+// for example because the payment webhook arrived while the invoice was being expired. It returns nil when nothing changed.
+func (oes *orderExpiryService) expireOrder(ctx context.Context, orderId string, now time.Time) (expiredOrder *entity.Order, err error) {
+	tx, err := oes.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err != nil && tx != nil {
+			tx.Rollback()
+		}
+	}()
+
+	orderRepository := oes.orderRepository.WithTransaction(tx)
+	productRepository := oes.productRepository.WithTransaction(tx)
+
+	orderEntity, err := orderRepository.GetOrderByIdForUpdate(ctx, orderId)
+	if err != nil {
+		return nil, err
+	}
+	if orderEntity == nil || orderEntity.OrderStatusCode != entity.OrderStatusCodeUnpaid {
+		err = tx.Commit()
+		return nil, err
+	}
+
+	err = transitionOrderStatus(ctx, orderRepository, orderEntity, orderStatusChange{
+		ToStatusCode: entity.OrderStatusCodeExpired,
+		Actor:        "System",
+		ActorRole:    entity.OrderActorSystem,
+		Reason:       "Order was not paid before it expired",
+		At:           now,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = restoreOrderStock(ctx, productRepository, orderEntity)
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, err
+	}
+	return orderEntity, nil
+}
+
+// expirePaymentInvoice closes the invoice on the payment provider. When the provider refuses, the order may only be
+// expired or canceled if the invoice is already expired there, otherwise a payment could still land on it (or already did).
+func expirePaymentInvoice(ctx context.Context, paymentGateway payment.PaymentGateway, invoiceId string) bool {
+	_, expireErr := paymentGateway.ExpireInvoice(ctx, invoiceId)
+	if expireErr == nil {
+		return true
+	}
+
+	paymentInvoice, getErr := paymentGateway.GetInvoice(ctx, invoiceId)
+	if getErr != nil {
+		slog.ErrorContext(ctx, "Expire invoice failed", "provider", paymentGateway.Provider(), "invoice_id", invoiceId, "error", expireErr)
+		return false
+	}
+	if paymentInvoice.Status != payment.InvoiceStatusExpired {
+		slog.WarnContext(ctx, "Skip expiring invoice", "provider", paymentGateway.Provider(), "invoice_id", invoiceId, "invoice_status", paymentInvoice.Status, "error", expireErr)
+		return false
+	}
+	return true
+}
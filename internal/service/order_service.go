@@ -1,497 +1,1089 @@
-package service
-
-import (
-	"context"
-	"database/sql"
-	"fmt"
-	operatingsystem "os"
-	"runtime/debug"
-	"time"
-
-	"github.com/arthurhzna/Golang_gRPC/internal/entity"
-	jwtentity "github.com/arthurhzna/Golang_gRPC/internal/entity/jwt"
-	"github.com/arthurhzna/Golang_gRPC/internal/repository"
-	"github.com/arthurhzna/Golang_gRPC/internal/utils"
-	"github.com/arthurhzna/Golang_gRPC/pb/order"
-	"github.com/google/uuid"
-	"github.com/xendit/xendit-go"
-	"github.com/xendit/xendit-go/invoice"
-	"google.golang.org/protobuf/types/known/timestamppb"
-)
-
-type IOrderService interface {
-	CreateOrder(ctx context.Context, req *order.CreateOrderRequest) (*order.CreateOrderResponse, error)
-	ListOrderAdmin(ctx context.Context, req *order.ListOrderAdminRequest) (*order.ListOrderAdminResponse, error)
-	ListOrder(ctx context.Context, req *order.ListOrderRequest) (*order.ListOrderResponse, error)
-	DetailOrder(ctx context.Context, request *order.DetailOrderRequest) (*order.DetailOrderResponse, error)
-	UpdateOrderStatus(ctx context.Context, request *order.UpdateOrderStatusRequest) (*order.UpdateOrderStatusResponse, error)
-}
-
-type orderService struct {
-	db                *sql.DB
-	orderRepository   repository.IOrderRepository
-	productRepository repository.IProductRepository
-}
-
-func NewOrderService(db *sql.DB, orderRepository repository.IOrderRepository, productRepository repository.IProductRepository) IOrderService {
-	return &orderService{
-		db:                db,
-		orderRepository:   orderRepository,
-		productRepository: productRepository,
-	}
-}
-
-func (os *orderService) CreateOrder(ctx context.Context, req *order.CreateOrderRequest) (*order.CreateOrderResponse, error) {
-	claims, err := jwtentity.GetClaimsFromContext(ctx)
-	if err != nil {
-		return nil, err
-	}
-
-	tx, err := os.db.BeginTx(ctx, nil)
-	if err != nil {
-		return nil, err
-	}
-	defer func() {
-		if e := recover(); e != nil {
-			if tx != nil {
-				tx.Rollback()
-			}
-			debug.PrintStack()
-			panic(e)
-		}
-	}()
-
-	defer func() {
-		if err != nil && tx != nil {
-			tx.Rollback()
-		}
-	}()
-
-	orderRepository := os.orderRepository.WithTransaction(tx)
-	productRepository := os.productRepository.WithTransaction(tx)
-
-	numbering, err := orderRepository.GetNumbering(ctx, "order")
-	if err != nil {
-		return nil, err
-	}
-
-	var productIds = make([]string, len(req.Products))
-	for i, product := range req.Products {
-		productIds[i] = product.Id
-	}
-
-	products, err := productRepository.GetProductsByIds(ctx, productIds)
-	if err != nil {
-		return nil, err
-	}
-
-	productMap := make(map[string]*entity.Product)
-	for i := range products {
-		productMap[products[i].Id] = products[i]
-	}
-
-	var total float64 = 0
-	for _, p := range req.Products {
-		if productMap[p.Id] == nil {
-			return &order.CreateOrderResponse{
-				Base: utils.NotFoundResponse(fmt.Sprintf("Product %s not found", p.Id)),
-			}, nil
-		}
-		total += productMap[p.Id].Price * float64(p.Quantity)
-	}
-
-	now := time.Now()
-	expiredAt := now.Add(24 * time.Hour)
-	orderEntity := entity.Order{
-		Id:              uuid.NewString(),
-		Number:          fmt.Sprintf("ORD-%d%08d", now.Year(), numbering.Number),
-		UserId:          claims.Subject,
-		OrderStatusCode: entity.OrderStatusCodeUnpaid,
-		UserFullName:    claims.FullName,
-		Address:         req.Address,
-		PhoneNumber:     req.PhoneNumber,
-		Notes:           &req.Notes,
-		Total:           total,
-		ExpiredAt:       &expiredAt,
-		CreatedAt:       now,
-		CreatedBy:       claims.FullName,
-	}
-	invoiceItems := make([]xendit.InvoiceItem, 0)
-	for _, p := range req.Products {
-		prod := productMap[p.Id]
-		if prod == nil {
-			invoiceItems = append(invoiceItems, xendit.InvoiceItem{
-				Name:     prod.Name,
-				Price:    prod.Price,
-				Quantity: int(p.Quantity),
-			})
-
-		}
-	}
-
-	XenditInvoice, XenditErr := invoice.CreateWithContext(ctx, &invoice.CreateParams{
-		ExternalID: orderEntity.Id,
-		Amount:     total,
-		Customer: xendit.InvoiceCustomer{
-			GivenNames: claims.FullName,
-		},
-		Currency:           "IDR",
-		SuccessRedirectURL: fmt.Sprintf("%s/checkout/%s/success", operatingsystem.Getenv("FE_BASE_URL"), orderEntity.Id),
-		Items:              invoiceItems,
-	})
-
-	if XenditErr != nil {
-		err = XenditErr
-		return nil, err
-	}
-
-	orderEntity.XenditInvoiceId = &XenditInvoice.ID
-	orderEntity.XenditInvoiceUrl = &XenditInvoice.InvoiceURL
-
-	err = orderRepository.CreateOrder(ctx, &orderEntity)
-	if err != nil {
-		return nil, err
-	}
-
-	for _, p := range req.Products {
-		var orderItem = entity.OrderItem{
-			Id:                   uuid.NewString(),
-			ProductId:            p.Id,
-			ProductName:          productMap[p.Id].Name,
-			ProductImageFileName: productMap[p.Id].ImageFileName,
-			ProductPrice:         productMap[p.Id].Price,
-			Quantity:             p.Quantity,
-			OrderId:              orderEntity.Id,
-			CreatedAt:            now,
-			CreatedBy:            claims.FullName,
-		}
-		err = orderRepository.CreateOrderItem(ctx, &orderItem)
-		if err != nil {
-			return nil, err
-		}
-	}
-
-	numbering.Number++
-
-	err = orderRepository.UpdateNumbering(ctx, numbering)
-	if err != nil {
-		return nil, err
-	}
-
-	err = tx.Commit()
-	if err != nil {
-		return nil, err
-	}
-
-	return &order.CreateOrderResponse{
-		Base: utils.SuccessResponse("Order created successfully"),
-		Id:   orderEntity.Id,
-	}, nil
-}
-
-func (os *orderService) ListOrderAdmin(ctx context.Context, req *order.ListOrderAdminRequest) (*order.ListOrderAdminResponse, error) {
-	claims, err := jwtentity.GetClaimsFromContext(ctx)
-	if err != nil {
-		return nil, err
-	}
-
-	if claims.Role != entity.UserRoleAdmin {
-		return nil, utils.UnaunthorizedResponse()
-	}
-
-	orders, metadata, err := os.orderRepository.GetListOrderAdminPagination(ctx, req.Pagination)
-	if err != nil {
-		return nil, err
-	}
-
-	items := make([]*order.ListOrderAdminResponseItem, 0)
-	for _, o := range orders {
-
-		products := make([]*order.ListOrderAdminResponseItemProduct, 0)
-
-		for _, orderItem := range o.Items {
-			products = append(products, &order.ListOrderAdminResponseItemProduct{
-				Id:       orderItem.ProductId,
-				Name:     orderItem.ProductName,
-				Price:    orderItem.ProductPrice,
-				Quantity: orderItem.Quantity,
-			})
-		}
-		orderStatusCode := o.OrderStatusCode
-		if o.OrderStatusCode == entity.OrderStatusCodeUnpaid && time.Now().After(*o.ExpiredAt) {
-			orderStatusCode = entity.OrderStatusCodeExpired
-		}
-		items = append(items, &order.ListOrderAdminResponseItem{
-			Id:         o.Id,
-			Number:     o.Number,
-			Customer:   o.UserFullName,
-			StatusCode: orderStatusCode,
-			Total:      o.Total,
-			CreatedAt:  timestamppb.New(o.CreatedAt),
-			Products:   products,
-		})
-	}
-	return &order.ListOrderAdminResponse{
-		Base:       utils.SuccessResponse("Orders fetched successfully"),
-		Pagination: metadata,
-		Data:       items,
-	}, nil
-}
-
-func (os *orderService) ListOrder(ctx context.Context, req *order.ListOrderRequest) (*order.ListOrderResponse, error) {
-	claims, err := jwtentity.GetClaimsFromContext(ctx)
-	if err != nil {
-		return nil, err
-	}
-
-	orders, metadata, err := os.orderRepository.GetListOrderPagination(ctx, req.Pagination, claims.Subject)
-	if err != nil {
-		return nil, err
-	}
-
-	items := make([]*order.ListOrderResponseItem, 0)
-	for _, o := range orders {
-
-		products := make([]*order.ListOrderResponseItemProduct, 0)
-
-		for _, orderItem := range o.Items {
-			products = append(products, &order.ListOrderResponseItemProduct{
-				Id:       orderItem.ProductId,
-				Name:     orderItem.ProductName,
-				Price:    orderItem.ProductPrice,
-				Quantity: orderItem.Quantity,
-			})
-		}
-		orderStatusCode := o.OrderStatusCode
-		if o.OrderStatusCode == entity.OrderStatusCodeUnpaid && time.Now().After(*o.ExpiredAt) {
-			orderStatusCode = entity.OrderStatusCodeExpired
-		}
-
-		xenditInvoiceUrl := ""
-		if o.XenditInvoiceUrl != nil {
-			xenditInvoiceUrl = *o.XenditInvoiceUrl
-		}
-		/*
-
-			ini ada penjelasannya pada \internal\repository\product_repository.go
-				Kalau punya pointer ke struct:
-				struct Product *entity.Product;
-				Untuk akses field harus:
-				(*entity.Product).Id
-				atau pakai entity.Product->Id
-				Karena C tidak otomatis melakukan dereference.
-
-				✅ Di Go
-				Kalau punya pointer ke struct:
-				product *entity.Product
-				Go mengizinkan:
-				product.Id
-				Padahal secara konsep ini sama dengan:
-				(*product).Id
-
-				Situasi 1: Akses Field dari Pointer Struct ✅ Otomatis Dereference
-
-				var productEntity *entity.Product  // pointer ke struct
-
-				// Go OTOMATIS dereference untuk akses field
-				productEntity.Id = ""        // ✅ Langsung bisa!
-				// Di belakang layar Go melakukan: (*productEntity).Id
-
-				Situasi 2: Field Itu Sendiri Adalah Pointer ⚠️ Harus Manual Dereference
-
-				type Order struct {
-					XenditInvoiceUrl *string  // Field ini POINTER ke string
-				}
-
-				var order *Order  // pointer ke struct
-
-				// Akses field (otomatis dereference struct)
-				order.XenditInvoiceUrl
-				// Tipe: *string (pointer ke string)
-				// Nilai: 0x12345678 (alamat memory)
-
-				// Dereference field pointer untuk dapat nilai
-				*order.XenditInvoiceUrl
-				// Tipe: string
-				// Nilai: "https://xendit.com/invoice/123"
-
-				// Memory Layout
-				┌────────────────────────────────────┐
-				│  Order struct (di address 0x1000)  │
-				│  ┌──────────────────────────────┐  │
-				│  │ XenditInvoiceUrl: 0x2000     │  │  <-- Field ini POINTER
-				│  └──────────────────────────────┘  │
-				└────────────────────────────────────┘
-								│
-								└──> ┌────────────────────────────────┐
-									 │ String (di address 0x2000)     │
-									 │ "https://xendit.com/..."       │
-									 └────────────────────────────────┘
-
-				var o *Order  // pointer ke struct Order
-
-				// 1. Akses struct (Go otomatis dereference)
-				o.XenditInvoiceUrl
-				// = 0x2000 (pointer ke string)
-
-				// 2. Dereference field pointer
-				*o.XenditInvoiceUrl
-				// = "https://xendit.com/..." (string value)
-
-				// Field biasa (bukan pointer)
-				type Product struct {
-					Id string  // bukan pointer
-				}
-				var p *Product
-				p.Id  // ✅ Langsung dapat string
-
-				// Field pointer
-				type Order struct {
-					XenditInvoiceUrl *string  // pointer!
-				}
-				var o *Order
-				o.XenditInvoiceUrl   // dapat *string (pointer)
-				*o.XenditInvoiceUrl  // dapat string (nilai)
-		*/
-
-		items = append(items, &order.ListOrderResponseItem{
-			Id:               o.Id,
-			Number:           o.Number,
-			Customer:         o.UserFullName,
-			StatusCode:       orderStatusCode,
-			Total:            o.Total,
-			CreatedAt:        timestamppb.New(o.CreatedAt),
-			Products:         products,
-			XenditInvoiceUrl: xenditInvoiceUrl,
-		})
-	}
-	return &order.ListOrderResponse{
-		Base:       utils.SuccessResponse("Orders fetched successfully"),
-		Pagination: metadata,
-		Data:       items,
-	}, nil
-}
-
-func (os *orderService) DetailOrder(ctx context.Context, request *order.DetailOrderRequest) (*order.DetailOrderResponse, error) {
-	claims, err := jwtentity.GetClaimsFromContext(ctx)
-	if err != nil {
-		return nil, err
-	}
-
-	orderEntity, err := os.orderRepository.GetOrderById(ctx, request.Id)
-	if err != nil {
-		return nil, err
-	}
-
-	if claims.Role != entity.UserRoleAdmin && claims.Subject != orderEntity.UserId {
-		return &order.DetailOrderResponse{
-			Base: utils.BadRequestResponse("User id is not matched"),
-		}, nil
-	}
-
-	notes := ""
-	if orderEntity.Notes != nil {
-		notes = *orderEntity.Notes
-	}
-	xenditInvoiceUrl := ""
-	if orderEntity.XenditInvoiceUrl != nil {
-		xenditInvoiceUrl = *orderEntity.XenditInvoiceUrl
-	}
-
-	orderStatusCode := orderEntity.OrderStatusCode
-	if orderEntity.OrderStatusCode == entity.OrderStatusCodeUnpaid && time.Now().After(*orderEntity.ExpiredAt) {
-		orderStatusCode = entity.OrderStatusCodeExpired
-	}
-
-	items := make([]*order.DetailOrderResponseItem, 0)
-	for _, oi := range orderEntity.Items {
-		items = append(items, &order.DetailOrderResponseItem{
-			Id:       oi.ProductId,
-			Name:     oi.ProductName,
-			Price:    oi.ProductPrice,
-			Quantity: oi.Quantity,
-		})
-	}
-	return &order.DetailOrderResponse{
-		Base:             utils.SuccessResponse("Get order detail success"),
-		Id:               orderEntity.Id,
-		Number:           orderEntity.Number,
-		UserFullName:     orderEntity.UserFullName,
-		Address:          orderEntity.Address,
-		PhoneNumber:      orderEntity.PhoneNumber,
-		Notes:            notes,
-		OrderStatusCode:  orderStatusCode,
-		CreatedAt:        timestamppb.New(orderEntity.CreatedAt),
-		XenditInvoiceUrl: xenditInvoiceUrl,
-		Items:            items,
-		Total:            orderEntity.Total,
-		ExpiredAt:        timestamppb.New(*orderEntity.ExpiredAt),
-	}, nil
-}
-
-func (os *orderService) UpdateOrderStatus(ctx context.Context, request *order.UpdateOrderStatusRequest) (*order.UpdateOrderStatusResponse, error) {
-	claims, err := jwtentity.GetClaimsFromContext(ctx)
-	if err != nil {
-		return nil, err
-	}
-	orderEntity, err := os.orderRepository.GetOrderById(ctx, request.OrderId)
-	if err != nil {
-		return nil, err
-	}
-	if orderEntity == nil {
-		return &order.UpdateOrderStatusResponse{
-			Base: utils.NotFoundResponse("Order not found"),
-		}, nil
-	}
-
-	if claims.Role != entity.UserRoleAdmin && orderEntity.UserId != claims.Subject {
-		return &order.UpdateOrderStatusResponse{
-			Base: utils.BadRequestResponse("User id is not matched"),
-		}, nil
-	}
-
-	if request.NewStatusCode == entity.OrderStatusCodePaid {
-		if claims.Role != entity.UserRoleAdmin || orderEntity.OrderStatusCode != entity.OrderStatusCodeUnpaid {
-			return &order.UpdateOrderStatusResponse{
-				Base: utils.BadRequestResponse("Update status is not allowed"),
-			}, nil
-		}
-	} else if request.NewStatusCode == entity.OrderStatusCodeCanceled {
-		if orderEntity.OrderStatusCode != entity.OrderStatusCodeUnpaid {
-			return &order.UpdateOrderStatusResponse{
-				Base: utils.BadRequestResponse("Update status is not allowed"),
-			}, nil
-		}
-	} else if request.NewStatusCode == entity.OrderStatusCodeShipped {
-		if claims.Role != entity.UserRoleAdmin || orderEntity.OrderStatusCode != entity.OrderStatusCodePaid {
-			return &order.UpdateOrderStatusResponse{
-				Base: utils.BadRequestResponse("Update status is not allowed"),
-			}, nil
-		}
-
-	} else if request.NewStatusCode == entity.OrderStatusCodeDone {
-		if orderEntity.OrderStatusCode != entity.OrderStatusCodeShipped {
-			return &order.UpdateOrderStatusResponse{
-				Base: utils.BadRequestResponse("Update status is not allowed"),
-			}, nil
-		}
-	} else {
-		return &order.UpdateOrderStatusResponse{
-			Base: utils.BadRequestResponse("Invalid new status code"),
-		}, nil
-	}
-
-	now := time.Now()
-	orderEntity.OrderStatusCode = request.NewStatusCode
-	orderEntity.UpdatedAt = &now
-	orderEntity.UpdatedBy = &claims.Subject
-
-	err = os.orderRepository.UpdateOrder(ctx, orderEntity)
-	if err != nil {
-		return nil, err
-	}
-
-	return &order.UpdateOrderStatusResponse{
-		Base: utils.SuccessResponse("Update order status success"),
-	}, nil
-}
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	operatingsystem "os"
+	"time"
+
+	"github.com/arthurhzna/Golang_gRPC/internal/domainerror"
+	"github.com/arthurhzna/Golang_gRPC/internal/entity"
+	jwtentity "github.com/arthurhzna/Golang_gRPC/internal/entity/jwt"
+	"github.com/arthurhzna/Golang_gRPC/internal/payment"
+	"github.com/arthurhzna/Golang_gRPC/internal/pubsub"
+	"github.com/arthurhzna/Golang_gRPC/internal/repository"
+	"github.com/arthurhzna/Golang_gRPC/internal/utils"
+	"github.com/arthurhzna/Golang_gRPC/pb/order"
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type IOrderService interface {
+	CreateOrder(ctx context.Context, req *order.CreateOrderRequest) (*order.CreateOrderResponse, error)
+	CheckoutCart(ctx context.Context, req *order.CheckoutCartRequest) (*order.CheckoutCartResponse, error)
+	ListOrderAdmin(ctx context.Context, req *order.ListOrderAdminRequest) (*order.ListOrderAdminResponse, error)
+	ListOrder(ctx context.Context, req *order.ListOrderRequest) (*order.ListOrderResponse, error)
+	DetailOrder(ctx context.Context, request *order.DetailOrderRequest) (*order.DetailOrderResponse, error)
+	UpdateOrderStatus(ctx context.Context, request *order.UpdateOrderStatusRequest) (*order.UpdateOrderStatusResponse, error)
+	RefundOrder(ctx context.Context, request *order.RefundOrderRequest) (*order.RefundOrderResponse, error)
+	GetOrderTimeline(ctx context.Context, request *order.GetOrderTimelineRequest) (*order.GetOrderTimelineResponse, error)
+	WatchOrder(request *order.WatchOrderRequest, stream grpc.ServerStreamingServer[order.WatchOrderResponse]) error
+}
+
+const emailNotVerifiedOrderMessage = "Verify your email before placing an order"
+
+type orderService struct {
+	db                      *sql.DB
+	orderRepository         repository.IOrderRepository
+	productRepository       repository.IProductRepository
+	orderRefundRepository   repository.IOrderRefundRepository
+	cartRepository          repository.ICartRepository
+	paymentGateway          payment.PaymentGateway
+	orderEventBus           pubsub.OrderEventBus
+	emailVerificationPolicy EmailVerificationPolicy
+}
+
+func NewOrderService(db *sql.DB, orderRepository repository.IOrderRepository, productRepository repository.IProductRepository, orderRefundRepository repository.IOrderRefundRepository, cartRepository repository.ICartRepository, paymentGateway payment.PaymentGateway, orderEventBus pubsub.OrderEventBus, emailVerificationPolicy EmailVerificationPolicy) IOrderService {
+	return &orderService{
+		db:                      db,
+		orderRepository:         orderRepository,
+		productRepository:       productRepository,
+		orderRefundRepository:   orderRefundRepository,
+		cartRepository:          cartRepository,
+		paymentGateway:          paymentGateway,
+		orderEventBus:           orderEventBus,
+		emailVerificationPolicy: emailVerificationPolicy,
+	}
+}
+
+func (os *orderService) CreateOrder(ctx context.Context, req *order.CreateOrderRequest) (*order.CreateOrderResponse, error) {
+	claims, err := jwtentity.GetClaimsFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if !claims.EmailVerified && os.emailVerificationPolicy.BlocksOrder() {
+		return nil, domainerror.FailedPrecondition("EMAIL_NOT_VERIFIED", emailNotVerifiedOrderMessage)
+	}
+
+	tx, err := os.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if e := recover(); e != nil {
+			if tx != nil {
+				tx.Rollback()
+			}
+			panic(e)
+		}
+	}()
+
+	defer func() {
+		if err != nil && tx != nil {
+			tx.Rollback()
+		}
+	}()
+
+	orderEntity, err := os.createOrder(ctx, tx, claims, req)
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, err
+	}
+
+	return &order.CreateOrderResponse{
+		Base: utils.SuccessResponse("Order created successfully"),
+		Id:   orderEntity.Id,
+	}, nil
+}
+
+func (os *orderService) CheckoutCart(ctx context.Context, req *order.CheckoutCartRequest) (*order.CheckoutCartResponse, error) {
+	claims, err := jwtentity.GetClaimsFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if !claims.EmailVerified && os.emailVerificationPolicy.BlocksOrder() {
+		return nil, domainerror.FailedPrecondition("EMAIL_NOT_VERIFIED", emailNotVerifiedOrderMessage)
+	}
+
+	tx, err := os.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err != nil && tx != nil {
+			tx.Rollback()
+		}
+	}()
+
+	cartRepository := os.cartRepository.WithTransaction(tx)
+
+	carts, err := cartRepository.GetListCartForUpdate(ctx, claims.Subject)
+	if err != nil {
+		return nil, err
+	}
+
+	checkoutCarts := carts
+	if len(req.CartIds) > 0 {
+		cartMap := make(map[string]*entity.Cart)
+		for _, cart := range carts {
+			cartMap[cart.Id] = cart
+		}
+		checkoutCarts = make([]*entity.Cart, 0)
+		for _, cartId := range req.CartIds {
+			if cartMap[cartId] == nil {
+				tx.Rollback()
+				return nil, domainerror.NotFound("CART_NOT_FOUND", fmt.Sprintf("Cart %s not found", cartId)).WithMetadata("cart_id", cartId)
+			}
+			checkoutCarts = append(checkoutCarts, cartMap[cartId])
+			delete(cartMap, cartId) // a cart id sent twice is checked out once
+		}
+	}
+	if len(checkoutCarts) == 0 {
+		tx.Rollback()
+		return nil, domainerror.FailedPrecondition("CART_EMPTY", "Cart is empty")
+	}
+
+	createOrderRequest := order.CreateOrderRequest{
+		FullName:    req.FullName,
+		Address:     req.Address,
+		PhoneNumber: req.PhoneNumber,
+		Notes:       req.Notes,
+	}
+	for _, cart := range checkoutCarts {
+		createOrderRequest.Products = append(createOrderRequest.Products, &order.CreateOrderRequestProductItem{
+			Id:       cart.ProductId,
+			Quantity: int64(cart.Quantity),
+		})
+	}
+
+	orderEntity, err := os.createOrder(ctx, tx, claims, &createOrderRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, cart := range checkoutCarts {
+		err = cartRepository.DeleteCart(ctx, cart.Id)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, err
+	}
+
+	return &order.CheckoutCartResponse{
+		Base: utils.SuccessResponse("Order created successfully"),
+		Id:   orderEntity.Id,
+	}, nil
+}
+
+// createOrder reserves the stock, creates the payment invoice and stores the order inside tx.
+// An error, including a domain error for a missing product or stock, means tx must be rolled back.
+func (os *orderService) createOrder(ctx context.Context, tx *sql.Tx, claims *jwtentity.JwtClaims, req *order.CreateOrderRequest) (*entity.Order, error) {
+	orderRepository := os.orderRepository.WithTransaction(tx)
+	productRepository := os.productRepository.WithTransaction(tx)
+
+	numbering, err := orderRepository.GetNumbering(ctx, "order")
+	if err != nil {
+		return nil, err
+	}
+
+	var productIds = make([]string, len(req.Products))
+	quantityMap := make(map[string]int64) // the same product can appear more than once in the request
+	for i, product := range req.Products {
+		productIds[i] = product.Id
+		quantityMap[product.Id] += product.Quantity
+	}
+
+	// lock the product rows until commit so concurrent orders can not oversell the same stock
+	products, err := productRepository.GetProductsByIdsForUpdate(ctx, productIds)
+	if err != nil {
+		return nil, err
+	}
+
+	productMap := make(map[string]*entity.Product)
+	for i := range products {
+		productMap[products[i].Id] = products[i]
+	}
+
+	var total float64 = 0
+	for _, p := range req.Products {
+		if productMap[p.Id] == nil {
+			return nil, domainerror.NotFound("PRODUCT_NOT_FOUND", fmt.Sprintf("Product %s not found", p.Id)).WithMetadata("product_id", p.Id)
+		}
+		if productMap[p.Id].Stock < quantityMap[p.Id] {
+			return nil, domainerror.FailedPrecondition("INSUFFICIENT_STOCK", fmt.Sprintf("Insufficient stock for product %s, available stock is %d", productMap[p.Id].Name, productMap[p.Id].Stock)).WithMetadata("product_id", p.Id)
+		}
+		total += productMap[p.Id].Price * float64(p.Quantity)
+	}
+
+	now := time.Now()
+	expiredAt := now.Add(24 * time.Hour)
+	orderEntity := entity.Order{
+		Id:              uuid.NewString(),
+		Number:          fmt.Sprintf("ORD-%d%08d", now.Year(), numbering.Number),
+		UserId:          claims.Subject,
+		OrderStatusCode: entity.OrderStatusCodeUnpaid,
+		UserFullName:    claims.FullName,
+		Address:         req.Address,
+		PhoneNumber:     req.PhoneNumber,
+		Notes:           &req.Notes,
+		Total:           total,
+		ExpiredAt:       &expiredAt,
+		CreatedAt:       now,
+		CreatedBy:       claims.FullName,
+	}
+	invoiceItems := make([]payment.InvoiceItem, 0)
+	for _, p := range req.Products {
+		prod := productMap[p.Id]
+		if prod != nil {
+			invoiceItems = append(invoiceItems, payment.InvoiceItem{
+				Name:     prod.Name,
+				Price:    prod.Price,
+				Quantity: p.Quantity,
+			})
+
+		}
+	}
+
+	paymentInvoice, err := os.paymentGateway.CreateInvoice(ctx, &payment.CreateInvoiceParams{
+		OrderId:            orderEntity.Id,
+		Amount:             total,
+		Currency:           "IDR",
+		CustomerName:       claims.FullName,
+		SuccessRedirectUrl: fmt.Sprintf("%s/checkout/%s/success", operatingsystem.Getenv("FE_BASE_URL"), orderEntity.Id),
+		ExpiredAt:          expiredAt,
+		Items:              invoiceItems,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	paymentProvider := os.paymentGateway.Provider()
+	orderEntity.PaymentProvider = &paymentProvider
+	orderEntity.PaymentInvoiceId = &paymentInvoice.Id
+	orderEntity.PaymentInvoiceUrl = &paymentInvoice.Url
+
+	err = orderRepository.CreateOrder(ctx, &orderEntity)
+	if err != nil {
+		return nil, err
+	}
+
+	err = orderRepository.CreateOrderStatusHistory(ctx, &entity.OrderStatusHistory{
+		Id:           uuid.NewString(),
+		OrderId:      orderEntity.Id,
+		ToStatusCode: orderEntity.OrderStatusCode,
+		Actor:        claims.Subject,
+		ActorRole:    entity.OrderActorCustomer,
+		Reason:       "Order created",
+		CreatedAt:    now,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, p := range req.Products {
+		var orderItem = entity.OrderItem{
+			Id:                   uuid.NewString(),
+			ProductId:            p.Id,
+			ProductName:          productMap[p.Id].Name,
+			ProductImageFileName: productMap[p.Id].ImageFileName,
+			ProductPrice:         productMap[p.Id].Price,
+			Quantity:             p.Quantity,
+			OrderId:              orderEntity.Id,
+			CreatedAt:            now,
+			CreatedBy:            claims.FullName,
+		}
+		err = orderRepository.CreateOrderItem(ctx, &orderItem)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for productId, quantity := range quantityMap {
+		err = productRepository.DecreaseProductStock(ctx, productId, quantity)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	numbering.Number++
+
+	err = orderRepository.UpdateNumbering(ctx, numbering)
+	if err != nil {
+		return nil, err
+	}
+
+	return &orderEntity, nil
+}
+
+func (os *orderService) ListOrderAdmin(ctx context.Context, req *order.ListOrderAdminRequest) (*order.ListOrderAdminResponse, error) {
+	orders, metadata, err := os.orderRepository.GetListOrderAdminPagination(ctx, req.Pagination)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]*order.ListOrderAdminResponseItem, 0)
+	for _, o := range orders {
+
+		products := make([]*order.ListOrderAdminResponseItemProduct, 0)
+
+		for _, orderItem := range o.Items {
+			products = append(products, &order.ListOrderAdminResponseItemProduct{
+				Id:       orderItem.ProductId,
+				Name:     orderItem.ProductName,
+				Price:    orderItem.ProductPrice,
+				Quantity: orderItem.Quantity,
+			})
+		}
+		orderStatusCode := o.OrderStatusCode
+		if o.OrderStatusCode == entity.OrderStatusCodeUnpaid && time.Now().After(*o.ExpiredAt) {
+			orderStatusCode = entity.OrderStatusCodeExpired
+		}
+		items = append(items, &order.ListOrderAdminResponseItem{
+			Id:         o.Id,
+			Number:     o.Number,
+			Customer:   o.UserFullName,
+			StatusCode: orderStatusCode,
+			Total:      o.Total,
+			CreatedAt:  timestamppb.New(o.CreatedAt),
+			Products:   products,
+		})
+	}
+	return &order.ListOrderAdminResponse{
+		Base:       utils.SuccessResponse("Orders fetched successfully"),
+		Pagination: metadata,
+		Data:       items,
+	}, nil
+}
+
+func (os *orderService) ListOrder(ctx context.Context, req *order.ListOrderRequest) (*order.ListOrderResponse, error) {
+	claims, err := jwtentity.GetClaimsFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	orders, metadata, err := os.orderRepository.GetListOrderPagination(ctx, req.Pagination, claims.Subject)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]*order.ListOrderResponseItem, 0)
+	for _, o := range orders {
+
+		products := make([]*order.ListOrderResponseItemProduct, 0)
+
+		for _, orderItem := range o.Items {
+			products = append(products, &order.ListOrderResponseItemProduct{
+				Id:       orderItem.ProductId,
+				Name:     orderItem.ProductName,
+				Price:    orderItem.ProductPrice,
+				Quantity: orderItem.Quantity,
+			})
+		}
+		orderStatusCode := o.OrderStatusCode
+		if o.OrderStatusCode == entity.OrderStatusCodeUnpaid && time.Now().After(*o.ExpiredAt) {
+			orderStatusCode = entity.OrderStatusCodeExpired
+		}
+
+		paymentInvoiceUrl := ""
+		if o.PaymentInvoiceUrl != nil {
+			paymentInvoiceUrl = *o.PaymentInvoiceUrl
+		}
+		/*
+
+			ini ada penjelasannya pada \internal\repository\product_repository.go
+				Kalau punya pointer ke struct:
+				struct Product *entity.Product;
+				Untuk akses field harus:
+				(*entity.Product).Id
+				atau pakai entity.Product->Id
+				Karena C tidak otomatis melakukan dereference.
+
+				✅ Di Go
+				Kalau punya pointer ke struct:
+				product *entity.Product
+				Go mengizinkan:
+				product.Id
+				Padahal secara konsep ini sama dengan:
+				(*product).Id
+
+				Situasi 1: Akses Field dari Pointer Struct ✅ Otomatis Dereference
+
+				var productEntity *entity.Product  // pointer ke struct
+
+				// Go OTOMATIS dereference untuk akses field
+				productEntity.Id = ""        // ✅ Langsung bisa!
+				// Di belakang layar Go melakukan: (*productEntity).Id
+
+				Situasi 2: Field Itu Sendiri Adalah Pointer ⚠️ Harus Manual Dereference
+
+				type Order struct {
+					XenditInvoiceUrl *string  // Field ini POINTER ke string
+				}
+
+				var order *Order  // pointer ke struct
+
+				// Akses field (otomatis dereference struct)
+				order.XenditInvoiceUrl
+				// Tipe: *string (pointer ke string)
+				// Nilai: 0x12345678 (alamat memory)
+
+				// Dereference field pointer untuk dapat nilai
+				*order.XenditInvoiceUrl
+				// Tipe: string
+				// Nilai: "https://xendit.com/invoice/123"
+
+				// Memory Layout
+				┌────────────────────────────────────┐
+				│  Order struct (di address 0x1000)  │
+				│  ┌──────────────────────────────┐  │
+				│  │ XenditInvoiceUrl: 0x2000     │  │  <-- Field ini POINTER
+				│  └──────────────────────────────┘  │
+				└────────────────────────────────────┘
+								│
+								└──> ┌────────────────────────────────┐
+									 │ String (di address 0x2000)     │
+									 │ "https://xendit.com/..."       │
+									 └────────────────────────────────┘
+
+				var o *Order  // pointer ke struct Order
+
+				// 1. Akses struct (Go otomatis dereference)
+				o.XenditInvoiceUrl
+				// = 0x2000 (pointer ke string)
+
+				// 2. Dereference field pointer
+				*o.XenditInvoiceUrl
+				// = "https://xendit.com/..." (string value)
+
+				// Field biasa (bukan pointer)
+				type Product struct {
+					Id string  // bukan pointer
+				}
+				var p *Product
+				p.Id  // ✅ Langsung dapat string
+
+				// Field pointer
+				type Order struct {
+					XenditInvoiceUrl *string  // pointer!
+				}
+				var o *Order
+				o.XenditInvoiceUrl   // dapat *string (pointer)
+				*o.XenditInvoiceUrl  // dapat string (nilai)
+		*/
+
+		items = append(items, &order.ListOrderResponseItem{
+			Id:                o.Id,
+			Number:            o.Number,
+			Customer:          o.UserFullName,
+			StatusCode:        orderStatusCode,
+			Total:             o.Total,
+			CreatedAt:         timestamppb.New(o.CreatedAt),
+			Products:          products,
+			PaymentInvoiceUrl: paymentInvoiceUrl,
+		})
+	}
+	return &order.ListOrderResponse{
+		Base:       utils.SuccessResponse("Orders fetched successfully"),
+		Pagination: metadata,
+		Data:       items,
+	}, nil
+}
+
+func (os *orderService) DetailOrder(ctx context.Context, request *order.DetailOrderRequest) (*order.DetailOrderResponse, error) {
+	claims, err := jwtentity.GetClaimsFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	orderEntity, err := os.orderRepository.GetOrderById(ctx, request.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	// the order of another user answers like a missing one, so order ids cannot be probed
+	if orderEntity == nil || (!entity.HasPermission(ctx, entity.PermissionOrderReadAll) && claims.Subject != orderEntity.UserId) {
+		return nil, domainerror.NotFound("ORDER_NOT_FOUND", "Order not found")
+	}
+
+	notes := ""
+	if orderEntity.Notes != nil {
+		notes = *orderEntity.Notes
+	}
+	paymentInvoiceUrl := ""
+	if orderEntity.PaymentInvoiceUrl != nil {
+		paymentInvoiceUrl = *orderEntity.PaymentInvoiceUrl
+	}
+
+	orderStatusCode := orderEntity.OrderStatusCode
+	if orderEntity.OrderStatusCode == entity.OrderStatusCodeUnpaid && time.Now().After(*orderEntity.ExpiredAt) {
+		orderStatusCode = entity.OrderStatusCodeExpired
+	}
+
+	items := make([]*order.DetailOrderResponseItem, 0)
+	for _, oi := range orderEntity.Items {
+		items = append(items, &order.DetailOrderResponseItem{
+			Id:               oi.ProductId,
+			Name:             oi.ProductName,
+			Price:            oi.ProductPrice,
+			Quantity:         oi.Quantity,
+			RefundedQuantity: oi.RefundedQuantity,
+		})
+	}
+	return &order.DetailOrderResponse{
+		Base:              utils.SuccessResponse("Get order detail success"),
+		Id:                orderEntity.Id,
+		Number:            orderEntity.Number,
+		UserFullName:      orderEntity.UserFullName,
+		Address:           orderEntity.Address,
+		PhoneNumber:       orderEntity.PhoneNumber,
+		Notes:             notes,
+		OrderStatusCode:   orderStatusCode,
+		CreatedAt:         timestamppb.New(orderEntity.CreatedAt),
+		PaymentInvoiceUrl: paymentInvoiceUrl,
+		Items:             items,
+		Total:             orderEntity.Total,
+		ExpiredAt:         timestamppb.New(*orderEntity.ExpiredAt),
+		RefundedTotal:     orderEntity.RefundedTotal,
+	}, nil
+}
+
+func (os *orderService) UpdateOrderStatus(ctx context.Context, request *order.UpdateOrderStatusRequest) (*order.UpdateOrderStatusResponse, error) {
+	claims, err := jwtentity.GetClaimsFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if request.NewStatusCode == entity.OrderStatusCodeCanceled {
+		err = os.expireInvoiceBeforeCancel(ctx, request.OrderId, claims)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	tx, err := os.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err != nil && tx != nil {
+			tx.Rollback()
+		}
+	}()
+
+	orderRepository := os.orderRepository.WithTransaction(tx)
+	productRepository := os.productRepository.WithTransaction(tx)
+
+	orderEntity, err := orderRepository.GetOrderByIdForUpdate(ctx, request.OrderId)
+	if err != nil {
+		return nil, err
+	}
+	if orderEntity == nil {
+		tx.Rollback()
+		return nil, domainerror.NotFound("ORDER_NOT_FOUND", "Order not found")
+	}
+
+	if !entity.HasPermission(ctx, entity.PermissionOrderManage) && orderEntity.UserId != claims.Subject {
+		tx.Rollback()
+		return nil, domainerror.NotFound("ORDER_NOT_FOUND", "Order not found")
+	}
+
+	if !entity.IsOrderStatusCode(request.NewStatusCode) {
+		tx.Rollback()
+		return nil, domainerror.InvalidArgument("INVALID_STATUS_CODE", "Invalid new status code").WithField("new_status_code")
+	}
+	if request.NewStatusCode == entity.OrderStatusCodeRefunded || request.NewStatusCode == entity.OrderStatusCodePartiallyRefunded {
+		tx.Rollback()
+		return nil, domainerror.FailedPrecondition("REFUND_REQUIRED", "Refund statuses are set by RefundOrder").WithField("new_status_code")
+	}
+
+	actorRole := entity.OrderActorCustomer
+	if entity.HasPermission(ctx, entity.PermissionOrderManage) {
+		actorRole = entity.OrderActorAdmin
+	}
+	reason := request.Reason
+	if reason == "" {
+		reason = fmt.Sprintf("Status changed to %s", request.NewStatusCode)
+	}
+
+	err = transitionOrderStatus(ctx, orderRepository, orderEntity, orderStatusChange{
+		ToStatusCode: request.NewStatusCode,
+		Actor:        claims.Subject,
+		ActorRole:    actorRole,
+		Reason:       reason,
+		At:           time.Now(),
+	})
+	if errors.Is(err, ErrOrderStatusTransitionNotAllowed) {
+		err = nil
+		tx.Rollback()
+		return nil, domainerror.FailedPrecondition("STATUS_TRANSITION_NOT_ALLOWED", "Update status is not allowed")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if request.NewStatusCode == entity.OrderStatusCodeCanceled {
+		err = restoreOrderStock(ctx, productRepository, orderEntity)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, err
+	}
+	publishOrderStatusEvent(ctx, os.orderEventBus, orderEntity)
+
+	return &order.UpdateOrderStatusResponse{
+		Base: utils.SuccessResponse("Update order status success"),
+	}, nil
+}
+
+// expireInvoiceBeforeCancel closes the payment invoice of an unpaid order before it is canceled, so the customer cannot
+// pay a canceled order whose stock was already given back. Like the expiry job it runs before the order is locked,
+// and UpdateOrderStatus checks the status again under the lock, so a payment that landed meanwhile fails the cancel.
+func (os *orderService) expireInvoiceBeforeCancel(ctx context.Context, orderId string, claims *jwtentity.JwtClaims) error {
+	orderEntity, err := os.orderRepository.GetOrderById(ctx, orderId)
+	if err != nil {
+		return err
+	}
+	if orderEntity == nil || (!entity.HasPermission(ctx, entity.PermissionOrderManage) && orderEntity.UserId != claims.Subject) {
+		return domainerror.NotFound("ORDER_NOT_FOUND", "Order not found")
+	}
+	// other statuses cannot be canceled, the transition check rejects them
+	if orderEntity.OrderStatusCode != entity.OrderStatusCodeUnpaid || orderEntity.PaymentInvoiceId == nil {
+		return nil
+	}
+
+	if !expirePaymentInvoice(ctx, os.paymentGateway, *orderEntity.PaymentInvoiceId) {
+		return domainerror.FailedPrecondition("INVOICE_NOT_EXPIRED", "The payment of the order could not be stopped, try again later")
+	}
+	return nil
+}
+
+func (os *orderService) RefundOrder(ctx context.Context, request *order.RefundOrderRequest) (*order.RefundOrderResponse, error) {
+	claims, err := jwtentity.GetClaimsFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// the refund is recorded as pending before the provider is called, so money never leaves without a ledger entry
+	orderRefund, invoiceId, err := os.createPendingOrderRefund(ctx, request, claims)
+	if err != nil {
+		return nil, err
+	}
+
+	paymentRefund, err := os.paymentGateway.RefundInvoice(ctx, &payment.RefundParams{
+		RefundId:  orderRefund.Id,
+		InvoiceId: invoiceId,
+		Amount:    orderRefund.Amount,
+		Reason:    orderRefund.Reason,
+	})
+	if err != nil {
+		if errors.Is(err, payment.ErrRefundRejected) {
+			failErr := os.failOrderRefund(ctx, orderRefund)
+			if failErr != nil {
+				return nil, failErr
+			}
+			return nil, domainerror.FailedPrecondition("REFUND_REJECTED", "Refund was rejected by the payment provider")
+		}
+		// the refund stays pending, retrying RefundOrder sends it again with the same idempotency key
+		return nil, err
+	}
+
+	orderEntity, completed, err := os.completeOrderRefund(ctx, orderRefund.Id, paymentRefund, claims.Subject)
+	if err != nil {
+		return nil, err
+	}
+	if completed {
+		publishOrderStatusEvent(ctx, os.orderEventBus, orderEntity)
+	}
+
+	return &order.RefundOrderResponse{
+		Base:            utils.SuccessResponse("Refund order success"),
+		RefundId:        orderRefund.Id,
+		RefundedAmount:  orderRefund.Amount,
+		OrderStatusCode: orderEntity.OrderStatusCode,
+	}, nil
+}
+
+// isSameRefundQuantity reports whether the refund covers exactly the quantity per product of refundQuantityMap.
+func isSameRefundQuantity(orderRefund *entity.OrderRefund, refundQuantityMap map[string]int64) bool {
+	refundedQuantityMap := make(map[string]int64)
+	for _, refundItem := range orderRefund.Items {
+		refundedQuantityMap[refundItem.ProductId] += refundItem.Quantity
+	}
+	for productId, quantity := range refundQuantityMap {
+		if refundedQuantityMap[productId] != quantity {
+			return false
+		}
+		delete(refundedQuantityMap, productId)
+	}
+	return len(refundedQuantityMap) == 0
+}
+
+// createPendingOrderRefund records the refund of the requested quantities as pending and returns it with the invoice to refund.
+// When the order already has a pending refund, from a call that failed before the provider answered, a retry asking for the
+// same quantities and restock gets that refund back, so it is finished rather than refunded twice; a different request fails
+// with REFUND_PENDING until it is finished.
+func (os *orderService) createPendingOrderRefund(ctx context.Context, request *order.RefundOrderRequest, claims *jwtentity.JwtClaims) (orderRefund *entity.OrderRefund, invoiceId string, err error) {
+	tx, err := os.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, "", err
+	}
+	defer func() {
+		if err != nil && tx != nil {
+			tx.Rollback()
+		}
+	}()
+
+	orderRepository := os.orderRepository.WithTransaction(tx)
+	orderRefundRepository := os.orderRefundRepository.WithTransaction(tx)
+
+	orderEntity, err := orderRepository.GetOrderByIdForUpdate(ctx, request.OrderId)
+	if err != nil {
+		return nil, "", err
+	}
+	if orderEntity == nil {
+		tx.Rollback()
+		return nil, "", domainerror.NotFound("ORDER_NOT_FOUND", "Order not found")
+	}
+	if orderEntity.PaymentInvoiceId == nil || orderEntity.PaymentProvider == nil || *orderEntity.PaymentProvider != os.paymentGateway.Provider() {
+		tx.Rollback()
+		return nil, "", domainerror.FailedPrecondition("PAYMENT_GATEWAY_MISMATCH", "Order was not paid through the configured payment gateway")
+	}
+
+	if !entity.CanTransitionOrderStatus(orderEntity.OrderStatusCode, entity.OrderStatusCodePartiallyRefunded, entity.OrderActorRefund) {
+		tx.Rollback()
+		return nil, "", domainerror.FailedPrecondition("ORDER_NOT_REFUNDABLE", fmt.Sprintf("Order with status %s can not be refunded", orderEntity.OrderStatusCode))
+	}
+
+	// quantity to refund per product, an empty request refunds everything that is left
+	refundQuantityMap := make(map[string]int64)
+	if len(request.Items) == 0 {
+		for _, item := range orderEntity.Items {
+			refundQuantityMap[item.ProductId] += item.Quantity - item.RefundedQuantity
+		}
+	} else {
+		for _, item := range request.Items {
+			refundQuantityMap[item.ProductId] += item.Quantity
+		}
+		for productId, requestedQuantity := range refundQuantityMap {
+			var refundableQuantity int64 = 0
+			for _, item := range orderEntity.Items {
+				if item.ProductId == productId {
+					refundableQuantity += item.Quantity - item.RefundedQuantity
+				}
+			}
+			if requestedQuantity > refundableQuantity {
+				tx.Rollback()
+				return nil, "", domainerror.InvalidArgument("REFUND_QUANTITY_EXCEEDED", fmt.Sprintf("Refund quantity for product %s exceeds the refundable quantity %d", productId, refundableQuantity)).WithMetadata("product_id", productId)
+			}
+		}
+	}
+
+	// refunded quantities only change once a refund completes, so a retry computes the same quantities as the pending refund
+	pendingRefund, err := orderRefundRepository.GetPendingOrderRefundByOrderId(ctx, orderEntity.Id)
+	if err != nil {
+		return nil, "", err
+	}
+	if pendingRefund != nil {
+		tx.Rollback()
+		if pendingRefund.Restock != request.Restock || !isSameRefundQuantity(pendingRefund, refundQuantityMap) {
+			return nil, "", domainerror.FailedPrecondition("REFUND_PENDING", "Another refund of this order is still pending, retry it with the same items before requesting a different one").WithMetadata("refund_id", pendingRefund.Id)
+		}
+		return pendingRefund, *orderEntity.PaymentInvoiceId, nil
+	}
+
+	now := time.Now()
+	orderRefund = &entity.OrderRefund{
+		Id:              uuid.NewString(),
+		OrderId:         orderEntity.Id,
+		Reason:          request.Reason,
+		Restock:         request.Restock,
+		Status:          entity.OrderRefundStatusPending,
+		PaymentProvider: orderEntity.PaymentProvider,
+		CreatedAt:       now,
+		CreatedBy:       claims.FullName,
+	}
+	// the same product can be spread over several order items, take from them in order
+	for _, item := range orderEntity.Items {
+		quantity := min(refundQuantityMap[item.ProductId], item.Quantity-item.RefundedQuantity)
+		if quantity <= 0 {
+			continue
+		}
+		refundQuantityMap[item.ProductId] -= quantity
+		amount := item.ProductPrice * float64(quantity)
+		orderRefund.Amount += amount
+		orderRefund.Items = append(orderRefund.Items, &entity.OrderRefundItem{
+			Id:            uuid.NewString(),
+			OrderRefundId: orderRefund.Id,
+			ProductId:     item.ProductId,
+			Quantity:      quantity,
+			Amount:        amount,
+			CreatedAt:     now,
+		})
+	}
+	if len(orderRefund.Items) == 0 {
+		tx.Rollback()
+		return nil, "", domainerror.FailedPrecondition("NOTHING_TO_REFUND", "Nothing left to refund")
+	}
+
+	err = orderRefundRepository.CreateOrderRefund(ctx, orderRefund)
+	if err != nil {
+		return nil, "", err
+	}
+	for _, refundItem := range orderRefund.Items {
+		err = orderRefundRepository.CreateOrderRefundItem(ctx, refundItem)
+		if err != nil {
+			return nil, "", err
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, "", err
+	}
+	return orderRefund, *orderEntity.PaymentInvoiceId, nil
+}
+
+// completeOrderRefund applies a refund the provider accepted: refunded quantities, stock when asked and the order status.
+// completed is false when the refund was already completed by another call and nothing changed.
+func (os *orderService) completeOrderRefund(ctx context.Context, orderRefundId string, paymentRefund *payment.Refund, actor string) (orderEntity *entity.Order, completed bool, err error) {
+	tx, err := os.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, false, err
+	}
+	defer func() {
+		if err != nil && tx != nil {
+			tx.Rollback()
+		}
+	}()
+
+	orderRepository := os.orderRepository.WithTransaction(tx)
+	productRepository := os.productRepository.WithTransaction(tx)
+	orderRefundRepository := os.orderRefundRepository.WithTransaction(tx)
+
+	orderRefund, err := orderRefundRepository.GetOrderRefundByIdForUpdate(ctx, orderRefundId)
+	if err != nil {
+		return nil, false, err
+	}
+	if orderRefund == nil {
+		return nil, false, fmt.Errorf("order refund %s not found", orderRefundId)
+	}
+	orderEntity, err = orderRepository.GetOrderByIdForUpdate(ctx, orderRefund.OrderId)
+	if err != nil {
+		return nil, false, err
+	}
+	if orderEntity == nil {
+		return nil, false, fmt.Errorf("order %s of refund %s not found", orderRefund.OrderId, orderRefund.Id)
+	}
+	if orderRefund.Status != entity.OrderRefundStatusPending {
+		err = tx.Commit()
+		return orderEntity, false, err
+	}
+
+	now := time.Now()
+	for _, refundItem := range orderRefund.Items {
+		quantity := refundItem.Quantity
+		for _, item := range orderEntity.Items {
+			if item.ProductId != refundItem.ProductId || quantity <= 0 {
+				continue
+			}
+			itemQuantity := min(quantity, item.Quantity-item.RefundedQuantity)
+			if itemQuantity <= 0 {
+				continue
+			}
+			quantity -= itemQuantity
+			item.RefundedQuantity += itemQuantity
+			item.UpdatedAt = &now
+			item.UpdatedBy = &actor
+			err = orderRepository.UpdateOrderItemRefundedQuantity(ctx, item)
+			if err != nil {
+				return nil, false, err
+			}
+		}
+
+		if orderRefund.Restock {
+			err = productRepository.IncreaseProductStock(ctx, refundItem.ProductId, refundItem.Quantity)
+			if err != nil {
+				return nil, false, err
+			}
+		}
+	}
+
+	toStatusCode := entity.OrderStatusCodeRefunded
+	for _, item := range orderEntity.Items {
+		if item.RefundedQuantity < item.Quantity {
+			toStatusCode = entity.OrderStatusCodePartiallyRefunded
+			break
+		}
+	}
+	orderEntity.RefundedTotal += orderRefund.Amount
+	err = transitionOrderStatus(ctx, orderRepository, orderEntity, orderStatusChange{
+		ToStatusCode: toStatusCode,
+		Actor:        actor,
+		ActorRole:    entity.OrderActorRefund,
+		Reason:       fmt.Sprintf("Refund %.2f: %s", orderRefund.Amount, orderRefund.Reason),
+		At:           now,
+	})
+	if err != nil {
+		return nil, false, err
+	}
+
+	orderRefund.Status = entity.OrderRefundStatusCompleted
+	orderRefund.PaymentRefundId = &paymentRefund.Id
+	orderRefund.UpdatedAt = &now
+	err = orderRefundRepository.UpdateOrderRefundResult(ctx, orderRefund)
+	if err != nil {
+		return nil, false, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, false, err
+	}
+	return orderEntity, true, nil
+}
+
+// failOrderRefund marks a refund the provider rejected as failed, nothing was applied to the order for it.
+func (os *orderService) failOrderRefund(ctx context.Context, orderRefund *entity.OrderRefund) error {
+	now := time.Now()
+	orderRefund.Status = entity.OrderRefundStatusFailed
+	orderRefund.UpdatedAt = &now
+	return os.orderRefundRepository.UpdateOrderRefundResult(ctx, orderRefund)
+}
+
+func (os *orderService) GetOrderTimeline(ctx context.Context, request *order.GetOrderTimelineRequest) (*order.GetOrderTimelineResponse, error) {
+	claims, err := jwtentity.GetClaimsFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	orderEntity, err := os.orderRepository.GetOrderById(ctx, request.OrderId)
+	if err != nil {
+		return nil, err
+	}
+	if orderEntity == nil {
+		return nil, domainerror.NotFound("ORDER_NOT_FOUND", "Order not found")
+	}
+
+	if !entity.HasPermission(ctx, entity.PermissionOrderReadAll) && claims.Subject != orderEntity.UserId {
+		return nil, domainerror.NotFound("ORDER_NOT_FOUND", "Order not found")
+	}
+
+	histories, err := os.orderRepository.GetOrderStatusHistories(ctx, orderEntity.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]*order.GetOrderTimelineResponseItem, 0)
+	for _, history := range histories {
+		fromStatusCode := ""
+		if history.FromStatusCode != nil {
+			fromStatusCode = *history.FromStatusCode
+		}
+		items = append(items, &order.GetOrderTimelineResponseItem{
+			FromStatusCode: fromStatusCode,
+			ToStatusCode:   history.ToStatusCode,
+			Actor:          history.Actor,
+			ActorRole:      history.ActorRole,
+			Reason:         history.Reason,
+			CreatedAt:      timestamppb.New(history.CreatedAt),
+		})
+	}
+
+	return &order.GetOrderTimelineResponse{
+		Base:  utils.SuccessResponse("Get order timeline success"),
+		Items: items,
+	}, nil
+}
+
+func (os *orderService) WatchOrder(request *order.WatchOrderRequest, stream grpc.ServerStreamingServer[order.WatchOrderResponse]) error {
+	ctx := stream.Context()
+	claims, err := jwtentity.GetClaimsFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	// subscribe before reading the order so a change in between is not missed
+	events, unsubscribe := os.orderEventBus.Subscribe(request.OrderId)
+	defer unsubscribe()
+
+	orderEntity, err := os.orderRepository.GetOrderById(ctx, request.OrderId)
+	if err != nil {
+		return err
+	}
+	if orderEntity == nil {
+		return domainerror.NotFound("ORDER_NOT_FOUND", "Order not found")
+	}
+
+	if !entity.HasPermission(ctx, entity.PermissionOrderReadAll) && claims.Subject != orderEntity.UserId {
+		return domainerror.NotFound("ORDER_NOT_FOUND", "Order not found")
+	}
+
+	updatedAt := orderEntity.CreatedAt
+	if orderEntity.UpdatedAt != nil {
+		updatedAt = *orderEntity.UpdatedAt
+	}
+	err = stream.Send(&order.WatchOrderResponse{
+		Base:            utils.SuccessResponse("Order status"),
+		OrderId:         orderEntity.Id,
+		OrderStatusCode: orderEntity.OrderStatusCode,
+		UpdatedAt:       timestamppb.New(updatedAt),
+	})
+	if err != nil {
+		return err
+	}
+
+	lastStatusCode := orderEntity.OrderStatusCode
+	for !entity.IsFinalOrderStatus(lastStatusCode) {
+		select {
+		case <-ctx.Done():
+			return nil
+		case event, ok := <-events:
+			if !ok {
+				return nil
+			}
+			if event.OrderStatusCode == lastStatusCode {
+				continue
+			}
+			err = stream.Send(&order.WatchOrderResponse{
+				Base:            utils.SuccessResponse("Order status changed"),
+				OrderId:         event.OrderId,
+				OrderStatusCode: event.OrderStatusCode,
+				UpdatedAt:       timestamppb.New(event.ChangedAt),
+			})
+			if err != nil {
+				return err
+			}
+			lastStatusCode = event.OrderStatusCode
+		}
+	}
+	return nil
+}
+
+// restoreOrderStock gives the reserved quantities of an order back to the products,
+// used when an unpaid order is canceled or expires.
+func restoreOrderStock(ctx context.Context, productRepository repository.IProductRepository, orderEntity *entity.Order) error {
+	for _, item := range orderEntity.Items {
+		err := productRepository.IncreaseProductStock(ctx, item.ProductId, item.Quantity)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/arthurhzna/Golang_gRPC/internal/entity"
+	"github.com/arthurhzna/Golang_gRPC/internal/pubsub"
+	"github.com/arthurhzna/Golang_gRPC/internal/repository"
+	"github.com/google/uuid"
+)
+
+var ErrOrderStatusTransitionNotAllowed = errors.New("order status transition is not allowed")
+
+type orderStatusChange struct {
+	ToStatusCode string
+	// Actor is the user id, or "System" for automated changes
+	Actor     string
+	ActorRole string
+	Reason    string
+	At        time.Time
+}
+
+// transitionOrderStatus moves the order along entity.OrderStatusTransitions and records the change in the order history.
+// The caller must hold the order row lock, other pending changes on orderEntity are saved as well.
+func transitionOrderStatus(ctx context.Context, orderRepository repository.IOrderRepository, orderEntity *entity.Order, change orderStatusChange) error {
+	fromStatusCode := orderEntity.OrderStatusCode
+	if !entity.CanTransitionOrderStatus(fromStatusCode, change.ToStatusCode, change.ActorRole) {
+		return ErrOrderStatusTransitionNotAllowed
+	}
+
+	orderEntity.OrderStatusCode = change.ToStatusCode
+	orderEntity.UpdatedAt = &change.At
+	orderEntity.UpdatedBy = &change.Actor
+	err := orderRepository.UpdateOrder(ctx, orderEntity)
+	if err != nil {
+		return err
+	}
+
+	return orderRepository.CreateOrderStatusHistory(ctx, &entity.OrderStatusHistory{
+		Id:             uuid.NewString(),
+		OrderId:        orderEntity.Id,
+		FromStatusCode: &fromStatusCode,
+		ToStatusCode:   change.ToStatusCode,
+		Actor:          change.Actor,
+		ActorRole:      change.ActorRole,
+		Reason:         change.Reason,
+		CreatedAt:      change.At,
+	})
+}
+
+// publishOrderStatusEvent tells WatchOrder streams about a committed status change.
+// The change is already saved, so a failure is only logged.
+func publishOrderStatusEvent(ctx context.Context, orderEventBus pubsub.OrderEventBus, orderEntity *entity.Order) {
+	changedAt := time.Now()
+	if orderEntity.UpdatedAt != nil {
+		changedAt = *orderEntity.UpdatedAt
+	}
+	err := orderEventBus.Publish(ctx, &pubsub.OrderStatusEvent{
+		OrderId:         orderEntity.Id,
+		UserId:          orderEntity.UserId,
+		OrderStatusCode: orderEntity.OrderStatusCode,
+		ChangedAt:       changedAt,
+	})
+	if err != nil {
+		slog.ErrorContext(ctx, "Publish order status failed", "order_id", orderEntity.Id, "status", orderEntity.OrderStatusCode, "error", err)
+	}
+}
@@ -0,0 +1,64 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/arthurhzna/Golang_gRPC/internal/repository"
+)
+
+// rolePermissionCacheTtl is how long a change in role_permission can take to apply.
+const rolePermissionCacheTtl = time.Minute
+
+type IPermissionService interface {
+	GetRolePermissions(ctx context.Context, roleCode string) (map[string]bool, error)
+}
+
+type cachedRolePermissions struct {
+	permissions map[string]bool
+	loadedAt    time.Time
+}
+
+type permissionService struct {
+	permissionRepository repository.IPermissionRepository
+
+	mu    sync.Mutex
+	cache map[string]*cachedRolePermissions
+}
+
+func NewPermissionService(permissionRepository repository.IPermissionRepository) IPermissionService {
+	return &permissionService{
+		permissionRepository: permissionRepository,
+		cache:                make(map[string]*cachedRolePermissions),
+	}
+}
+
+// GetRolePermissions returns the permission set of a role, read from the database at most once per rolePermissionCacheTtl.
+// The returned map is shared and must not be modified.
+func (ps *permissionService) GetRolePermissions(ctx context.Context, roleCode string) (map[string]bool, error) {
+	ps.mu.Lock()
+	cached, ok := ps.cache[roleCode]
+	ps.mu.Unlock()
+	if ok && time.Since(cached.loadedAt) < rolePermissionCacheTtl {
+		return cached.permissions, nil
+	}
+
+	codes, err := ps.permissionRepository.GetPermissionCodesByRoleCode(ctx, roleCode)
+	if err != nil {
+		return nil, err
+	}
+	permissions := make(map[string]bool)
+	for _, code := range codes {
+		permissions[code] = true
+	}
+
+	ps.mu.Lock()
+	ps.cache[roleCode] = &cachedRolePermissions{
+		permissions: permissions,
+		loadedAt:    time.Now(),
+	}
+	ps.mu.Unlock()
+
+	return permissions, nil
+}
@@ -0,0 +1,292 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/arthurhzna/Golang_gRPC/internal/domainerror"
+	"github.com/arthurhzna/Golang_gRPC/internal/entity"
+	jwtentity "github.com/arthurhzna/Golang_gRPC/internal/entity/jwt"
+	"github.com/arthurhzna/Golang_gRPC/internal/repository"
+	"github.com/arthurhzna/Golang_gRPC/internal/utils"
+	"github.com/arthurhzna/Golang_gRPC/pb/serviceaccount"
+)
+
+const (
+	// apiKeyPrefix marks the keys of this API, so a leaked key is easy to recognize in logs and secret scanners
+	apiKeyPrefix = "sak_"
+	// the last used timestamp is written at most once per interval, not on every call
+	apiKeyLastUsedInterval = time.Minute
+)
+
+type IServiceAccountService interface {
+	CreateServiceAccount(ctx context.Context, req *serviceaccount.CreateServiceAccountRequest) (*serviceaccount.CreateServiceAccountResponse, error)
+	ListServiceAccounts(ctx context.Context, req *serviceaccount.ListServiceAccountsRequest) (*serviceaccount.ListServiceAccountsResponse, error)
+	CreateApiKey(ctx context.Context, req *serviceaccount.CreateApiKeyRequest) (*serviceaccount.CreateApiKeyResponse, error)
+	ListApiKeys(ctx context.Context, req *serviceaccount.ListApiKeysRequest) (*serviceaccount.ListApiKeysResponse, error)
+	RevokeApiKey(ctx context.Context, req *serviceaccount.RevokeApiKeyRequest) (*serviceaccount.RevokeApiKeyResponse, error)
+	// AuthenticateApiKey returns nil when the key is unknown, revoked or expired, the auth middleware calls it for every request with x-api-key.
+	AuthenticateApiKey(ctx context.Context, rawApiKey string) (*entity.ServiceAccount, *entity.ApiKey, error)
+}
+
+type serviceAccountService struct {
+	serviceAccountRepository repository.IServiceAccountRepository
+	apiKeyRepository         repository.IApiKeyRepository
+	userRepository           repository.IUserRepository
+	// isApiKeyScope reports whether a gRPC method may be put in the scopes of an API key
+	isApiKeyScope func(fullMethod string) bool
+}
+
+func NewServiceAccountService(serviceAccountRepository repository.IServiceAccountRepository, apiKeyRepository repository.IApiKeyRepository, userRepository repository.IUserRepository, isApiKeyScope func(fullMethod string) bool) IServiceAccountService {
+	return &serviceAccountService{
+		serviceAccountRepository: serviceAccountRepository,
+		apiKeyRepository:         apiKeyRepository,
+		userRepository:           userRepository,
+		isApiKeyScope:            isApiKeyScope,
+	}
+}
+
+func (ss *serviceAccountService) CreateServiceAccount(ctx context.Context, req *serviceaccount.CreateServiceAccountRequest) (*serviceaccount.CreateServiceAccountResponse, error) {
+
+	claims, err := jwtentity.GetClaimsFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	userRole, err := ss.userRepository.GetUserRoleByCode(ctx, req.RoleCode)
+	if err != nil {
+		return nil, err
+	}
+	if userRole == nil {
+		return nil, domainerror.InvalidArgument("ROLE_NOT_FOUND", "Role not found").WithField("role_code")
+	}
+
+	serviceAccount := &entity.ServiceAccount{
+		Id:        uuid.New().String(),
+		Name:      req.Name,
+		RoleCode:  userRole.Code,
+		CreatedAt: time.Now(),
+		CreatedBy: claims.FullName,
+	}
+	err = ss.serviceAccountRepository.CreateServiceAccount(ctx, serviceAccount)
+	if err != nil {
+		return nil, err
+	}
+
+	return &serviceaccount.CreateServiceAccountResponse{
+		Base: utils.SuccessResponse("Service account created successfully"),
+		Data: toServiceAccountItem(serviceAccount),
+	}, nil
+}
+
+func (ss *serviceAccountService) ListServiceAccounts(ctx context.Context, req *serviceaccount.ListServiceAccountsRequest) (*serviceaccount.ListServiceAccountsResponse, error) {
+
+	serviceAccounts, err := ss.serviceAccountRepository.GetServiceAccounts(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var data []*serviceaccount.ServiceAccountItem = make([]*serviceaccount.ServiceAccountItem, 0)
+	for _, serviceAccount := range serviceAccounts {
+		data = append(data, toServiceAccountItem(serviceAccount))
+	}
+
+	return &serviceaccount.ListServiceAccountsResponse{
+		Base: utils.SuccessResponse("List service accounts successfully"),
+		Data: data,
+	}, nil
+}
+
+func (ss *serviceAccountService) CreateApiKey(ctx context.Context, req *serviceaccount.CreateApiKeyRequest) (*serviceaccount.CreateApiKeyResponse, error) {
+
+	claims, err := jwtentity.GetClaimsFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	serviceAccount, err := ss.serviceAccountRepository.GetServiceAccountById(ctx, req.ServiceAccountId)
+	if err != nil {
+		return nil, err
+	}
+	if serviceAccount == nil {
+		return nil, domainerror.NotFound("SERVICE_ACCOUNT_NOT_FOUND", "Service account not found")
+	}
+
+	for i, scope := range req.Scopes {
+		if !ss.isApiKeyScope(scope) {
+			return nil, domainerror.InvalidArgument("INVALID_API_KEY_SCOPE", fmt.Sprintf("Method %s cannot be called with an API key", scope)).WithField(fmt.Sprintf("scopes[%d]", i))
+		}
+	}
+
+	now := time.Now()
+	var expiresAt *time.Time
+	if req.ExpiresAt != nil {
+		expiresAtTime := req.ExpiresAt.AsTime()
+		if !expiresAtTime.After(now) {
+			return nil, domainerror.InvalidArgument("INVALID_EXPIRES_AT", "Expires at must be in the future").WithField("expires_at")
+		}
+		expiresAt = &expiresAtTime
+	}
+
+	prefix, rawApiKey, err := generateApiKey()
+	if err != nil {
+		return nil, err
+	}
+
+	apiKey := &entity.ApiKey{
+		Id:               uuid.New().String(),
+		ServiceAccountId: serviceAccount.Id,
+		Name:             req.Name,
+		Prefix:           prefix,
+		KeyHash:          hashOpaqueToken(rawApiKey),
+		Scopes:           req.Scopes,
+		CreatedAt:        now,
+		CreatedBy:        claims.FullName,
+		ExpiresAt:        expiresAt,
+	}
+	err = ss.apiKeyRepository.CreateApiKey(ctx, apiKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return &serviceaccount.CreateApiKeyResponse{
+		Base:   utils.SuccessResponse("API key created successfully, it is only shown once"),
+		ApiKey: rawApiKey,
+		Data:   toApiKeyItem(apiKey),
+	}, nil
+}
+
+func (ss *serviceAccountService) ListApiKeys(ctx context.Context, req *serviceaccount.ListApiKeysRequest) (*serviceaccount.ListApiKeysResponse, error) {
+
+	serviceAccount, err := ss.serviceAccountRepository.GetServiceAccountById(ctx, req.ServiceAccountId)
+	if err != nil {
+		return nil, err
+	}
+	if serviceAccount == nil {
+		return nil, domainerror.NotFound("SERVICE_ACCOUNT_NOT_FOUND", "Service account not found")
+	}
+
+	apiKeys, err := ss.apiKeyRepository.GetApiKeysByServiceAccountId(ctx, serviceAccount.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	var data []*serviceaccount.ApiKeyItem = make([]*serviceaccount.ApiKeyItem, 0)
+	for _, apiKey := range apiKeys {
+		data = append(data, toApiKeyItem(apiKey))
+	}
+
+	return &serviceaccount.ListApiKeysResponse{
+		Base: utils.SuccessResponse("List API keys successfully"),
+		Data: data,
+	}, nil
+}
+
+func (ss *serviceAccountService) RevokeApiKey(ctx context.Context, req *serviceaccount.RevokeApiKeyRequest) (*serviceaccount.RevokeApiKeyResponse, error) {
+
+	apiKey, err := ss.apiKeyRepository.GetApiKeyById(ctx, req.Id)
+	if err != nil {
+		return nil, err
+	}
+	if apiKey == nil {
+		return nil, domainerror.NotFound("API_KEY_NOT_FOUND", "API key not found")
+	}
+	if apiKey.RevokedAt != nil {
+		return nil, domainerror.FailedPrecondition("API_KEY_REVOKED", "API key is already revoked")
+	}
+
+	err = ss.apiKeyRepository.RevokeApiKey(ctx, apiKey.Id, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	return &serviceaccount.RevokeApiKeyResponse{
+		Base: utils.SuccessResponse("API key revoked successfully"),
+	}, nil
+}
+
+func (ss *serviceAccountService) AuthenticateApiKey(ctx context.Context, rawApiKey string) (*entity.ServiceAccount, *entity.ApiKey, error) {
+
+	apiKey, err := ss.apiKeyRepository.GetApiKeyByHash(ctx, hashOpaqueToken(rawApiKey))
+	if err != nil {
+		return nil, nil, err
+	}
+	now := time.Now()
+	if apiKey == nil || apiKey.RevokedAt != nil || (apiKey.ExpiresAt != nil && !now.Before(*apiKey.ExpiresAt)) {
+		return nil, nil, nil
+	}
+
+	serviceAccount, err := ss.serviceAccountRepository.GetServiceAccountById(ctx, apiKey.ServiceAccountId)
+	if err != nil {
+		return nil, nil, err
+	}
+	if serviceAccount == nil {
+		return nil, nil, nil
+	}
+
+	if apiKey.LastUsedAt == nil || now.Sub(*apiKey.LastUsedAt) >= apiKeyLastUsedInterval {
+		err = ss.apiKeyRepository.UpdateApiKeyLastUsed(ctx, apiKey.Id, now)
+		if err != nil {
+			return nil, nil, err
+		}
+		apiKey.LastUsedAt = &now
+	}
+
+	return serviceAccount, apiKey, nil
+}
+
+// generateApiKey returns a key like "sak_1a2b3c4d_<secret>" and its prefix, which is kept to tell keys apart.
+func generateApiKey() (string, string, error) {
+	prefixBytes := make([]byte, 4)
+	_, err := rand.Read(prefixBytes)
+	if err != nil {
+		return "", "", err
+	}
+	secretBytes := make([]byte, 32)
+	_, err = rand.Read(secretBytes)
+	if err != nil {
+		return "", "", err
+	}
+
+	prefix := apiKeyPrefix + hex.EncodeToString(prefixBytes)
+	return prefix, prefix + "_" + base64.RawURLEncoding.EncodeToString(secretBytes), nil
+}
+
+func toServiceAccountItem(serviceAccount *entity.ServiceAccount) *serviceaccount.ServiceAccountItem {
+	return &serviceaccount.ServiceAccountItem{
+		Id:        serviceAccount.Id,
+		Name:      serviceAccount.Name,
+		RoleCode:  serviceAccount.RoleCode,
+		CreatedAt: timestamppb.New(serviceAccount.CreatedAt),
+		CreatedBy: serviceAccount.CreatedBy,
+	}
+}
+
+func toApiKeyItem(apiKey *entity.ApiKey) *serviceaccount.ApiKeyItem {
+	apiKeyItem := &serviceaccount.ApiKeyItem{
+		Id:               apiKey.Id,
+		ServiceAccountId: apiKey.ServiceAccountId,
+		Name:             apiKey.Name,
+		Prefix:           apiKey.Prefix,
+		Scopes:           apiKey.Scopes,
+		CreatedAt:        timestamppb.New(apiKey.CreatedAt),
+		CreatedBy:        apiKey.CreatedBy,
+	}
+	if apiKey.ExpiresAt != nil {
+		apiKeyItem.ExpiresAt = timestamppb.New(*apiKey.ExpiresAt)
+	}
+	if apiKey.LastUsedAt != nil {
+		apiKeyItem.LastUsedAt = timestamppb.New(*apiKey.LastUsedAt)
+	}
+	if apiKey.RevokedAt != nil {
+		apiKeyItem.RevokedAt = timestamppb.New(*apiKey.RevokedAt)
+	}
+	return apiKeyItem
+}
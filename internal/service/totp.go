@@ -0,0 +1,88 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base32"
+	"strings"
+	"time"
+
+	"github.com/pquerna/otp"
+	"github.com/pquerna/otp/totp"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/arthurhzna/Golang_gRPC/internal/entity"
+	"github.com/arthurhzna/Golang_gRPC/internal/repository"
+)
+
+const (
+	defaultTotpIssuer = "Golang gRPC"
+	totpPeriod        = 30
+	// codes of the previous and next period are accepted too, for clocks that drift a little
+	totpSkew              = 1
+	totpRecoveryCodeCount = 10
+	// a challenge allows a few typos, the email lockout of Login covers guessing across challenges
+	loginChallengeDuration    = time.Minute * 5
+	loginChallengeMaxFailures = 5
+)
+
+var (
+	ErrLoginChallengeInvalid = status.Errorf(codes.Unauthenticated, "Invalid or expired login challenge")
+	ErrLoginInvalidTotpCode  = status.Errorf(codes.Unauthenticated, "Invalid two-factor code")
+)
+
+var totpValidateOpts = totp.ValidateOpts{
+	Period:    totpPeriod,
+	Digits:    otp.DigitsSix,
+	Algorithm: otp.AlgorithmSHA1,
+}
+
+// verifyTotpCode checks a code of the authenticator app and records its time step, so each code works once.
+func verifyTotpCode(ctx context.Context, authRepo repository.IAuthRepository, user *entity.User, code string, now time.Time) (bool, error) {
+	if user.TotpSecret == nil {
+		return false, nil
+	}
+
+	currentStep := now.Unix() / totpPeriod
+	for skew := int64(-totpSkew); skew <= totpSkew; skew++ {
+		step := currentStep + skew
+		expectedCode, err := totp.GenerateCodeCustom(*user.TotpSecret, time.Unix(step*totpPeriod, 0), totpValidateOpts)
+		if err != nil {
+			return false, err
+		}
+		if subtle.ConstantTimeCompare([]byte(expectedCode), []byte(code)) == 1 {
+			return authRepo.UseUserTotpStep(ctx, user.Id, step)
+		}
+	}
+	return false, nil
+}
+
+// verifySecondFactor accepts a 6 digit code of the authenticator app or one of the recovery codes.
+func verifySecondFactor(ctx context.Context, authRepo repository.IAuthRepository, recoveryCodeRepo repository.ITotpRecoveryCodeRepository, user *entity.User, code string, now time.Time) (bool, error) {
+	code = strings.TrimSpace(code)
+	if len(code) == int(otp.DigitsSix) {
+		return verifyTotpCode(ctx, authRepo, user, code, now)
+	}
+	return recoveryCodeRepo.UseTotpRecoveryCode(ctx, user.Id, hashOpaqueToken(normalizeRecoveryCode(code)), now)
+}
+
+// generateRecoveryCodes returns codes formatted like "abcde-fghij", only their hashes are stored.
+func generateRecoveryCodes() ([]string, error) {
+	recoveryCodes := make([]string, 0, totpRecoveryCodeCount)
+	for i := 0; i < totpRecoveryCodeCount; i++ {
+		codeBytes := make([]byte, 10)
+		_, err := rand.Read(codeBytes)
+		if err != nil {
+			return nil, err
+		}
+		code := strings.ToLower(base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString(codeBytes))[:10]
+		recoveryCodes = append(recoveryCodes, code[:5]+"-"+code[5:])
+	}
+	return recoveryCodes, nil
+}
+
+func normalizeRecoveryCode(code string) string {
+	return strings.ToLower(strings.ReplaceAll(strings.TrimSpace(code), "-", ""))
+}
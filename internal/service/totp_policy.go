@@ -0,0 +1,28 @@
+package service
+
+import (
+	"os"
+	"strings"
+)
+
+// TotpPolicy lists the roles that must enable two-factor authentication before their permissions can be used.
+type TotpPolicy struct {
+	requiredRoles map[string]bool
+}
+
+// NewTotpPolicyFromEnv reads TOTP_REQUIRED_ROLES, a comma separated list of role codes such as "admin".
+// Nothing is required when it is not set.
+func NewTotpPolicyFromEnv() TotpPolicy {
+	requiredRoles := make(map[string]bool)
+	for _, roleCode := range strings.Split(os.Getenv("TOTP_REQUIRED_ROLES"), ",") {
+		roleCode = strings.TrimSpace(roleCode)
+		if roleCode != "" {
+			requiredRoles[roleCode] = true
+		}
+	}
+	return TotpPolicy{requiredRoles: requiredRoles}
+}
+
+func (p TotpPolicy) RequiredFor(roleCode string) bool {
+	return p.requiredRoles[roleCode]
+}
@@ -0,0 +1,279 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/arthurhzna/Golang_gRPC/internal/domainerror"
+	"github.com/arthurhzna/Golang_gRPC/internal/entity"
+	jwtentity "github.com/arthurhzna/Golang_gRPC/internal/entity/jwt"
+	"github.com/arthurhzna/Golang_gRPC/internal/repository"
+	"github.com/arthurhzna/Golang_gRPC/internal/utils"
+	"github.com/arthurhzna/Golang_gRPC/pb/common"
+	"github.com/arthurhzna/Golang_gRPC/pb/user"
+)
+
+type IUserService interface {
+	ListUsers(ctx context.Context, req *user.ListUsersRequest) (*user.ListUsersResponse, error)
+	GetUser(ctx context.Context, req *user.GetUserRequest) (*user.GetUserResponse, error)
+	ChangeUserRole(ctx context.Context, req *user.ChangeUserRoleRequest) (*user.ChangeUserRoleResponse, error)
+	DisableUser(ctx context.Context, req *user.DisableUserRequest) (*user.DisableUserResponse, error)
+	RestoreUser(ctx context.Context, req *user.RestoreUserRequest) (*user.RestoreUserResponse, error)
+	// GetActiveUser returns nil when the user does not exist or is disabled, the auth middleware calls it on every request.
+	GetActiveUser(ctx context.Context, userId string) (*entity.User, error)
+	// GetActiveSession returns nil when the session is revoked, expired or not the user's,
+	// it records the call as last seen at most once a minute.
+	GetActiveSession(ctx context.Context, userId string, sessionId string) (*entity.UserSession, error)
+}
+
+// sessionLastSeenInterval limits the last seen writes to one per minute for a busy session
+const sessionLastSeenInterval = time.Minute
+
+type userService struct {
+	db                     *sql.DB
+	userRepository         repository.IUserRepository
+	refreshTokenRepository repository.IRefreshTokenRepository
+	userSessionRepository  repository.IUserSessionRepository
+}
+
+func NewUserService(db *sql.DB, userRepository repository.IUserRepository, refreshTokenRepository repository.IRefreshTokenRepository, userSessionRepository repository.IUserSessionRepository) IUserService {
+	return &userService{
+		db:                     db,
+		userRepository:         userRepository,
+		refreshTokenRepository: refreshTokenRepository,
+		userSessionRepository:  userSessionRepository,
+	}
+}
+
+func (us *userService) ListUsers(ctx context.Context, req *user.ListUsersRequest) (*user.ListUsersResponse, error) {
+
+	pagination := req.Pagination
+	if pagination == nil {
+		pagination = &common.PaginationRequest{}
+	}
+	if pagination.CurrentPage <= 0 {
+		pagination.CurrentPage = 1
+	}
+	if pagination.ItemPerPage <= 0 {
+		pagination.ItemPerPage = 10
+	}
+
+	users, paginationResponse, err := us.userRepository.GetUsersByPagination(ctx, pagination, &repository.UserFilter{
+		Search:          req.Search,
+		RoleCode:        req.RoleCode,
+		IncludeDisabled: req.IncludeDisabled,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var data []*user.UserItem = make([]*user.UserItem, 0)
+	for _, u := range users {
+		data = append(data, toUserItem(u))
+	}
+
+	return &user.ListUsersResponse{
+		Base:       utils.SuccessResponse("List users successfully"),
+		Pagination: paginationResponse,
+		Data:       data,
+	}, nil
+}
+
+func (us *userService) GetUser(ctx context.Context, req *user.GetUserRequest) (*user.GetUserResponse, error) {
+
+	userEntity, err := us.userRepository.GetUserById(ctx, req.Id)
+	if err != nil {
+		return nil, err
+	}
+	if userEntity == nil {
+		return nil, domainerror.NotFound("USER_NOT_FOUND", "User not found")
+	}
+
+	return &user.GetUserResponse{
+		Base: utils.SuccessResponse("Get user successfully"),
+		Data: toUserItem(userEntity),
+	}, nil
+}
+
+func (us *userService) ChangeUserRole(ctx context.Context, req *user.ChangeUserRoleRequest) (*user.ChangeUserRoleResponse, error) {
+
+	claims, err := jwtentity.GetClaimsFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// an admin demoting themself could leave nobody able to manage users
+	if req.Id == claims.Subject {
+		return nil, domainerror.PermissionDenied("CANNOT_CHANGE_OWN_ROLE", "You cannot change your own role")
+	}
+
+	userEntity, err := us.userRepository.GetUserById(ctx, req.Id)
+	if err != nil {
+		return nil, err
+	}
+	if userEntity == nil {
+		return nil, domainerror.NotFound("USER_NOT_FOUND", "User not found")
+	}
+
+	userRole, err := us.userRepository.GetUserRoleByCode(ctx, req.RoleCode)
+	if err != nil {
+		return nil, err
+	}
+	if userRole == nil {
+		return nil, domainerror.InvalidArgument("ROLE_NOT_FOUND", "Role not found").WithField("role_code")
+	}
+
+	err = us.userRepository.UpdateUserRole(ctx, userEntity.Id, userRole.Code, claims.FullName)
+	if err != nil {
+		return nil, err
+	}
+
+	return &user.ChangeUserRoleResponse{
+		Base: utils.SuccessResponse("User role changed successfully"),
+	}, nil
+}
+
+func (us *userService) DisableUser(ctx context.Context, req *user.DisableUserRequest) (*user.DisableUserResponse, error) {
+
+	claims, err := jwtentity.GetClaimsFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Id == claims.Subject {
+		return nil, domainerror.PermissionDenied("CANNOT_DISABLE_SELF", "You cannot disable your own account")
+	}
+
+	tx, err := us.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err != nil && tx != nil {
+			tx.Rollback()
+		}
+	}()
+
+	userRepository := us.userRepository.WithTransaction(tx)
+
+	userEntity, err := userRepository.GetUserByIdForUpdate(ctx, req.Id)
+	if err != nil {
+		return nil, err
+	}
+	if userEntity == nil {
+		tx.Rollback()
+		return nil, domainerror.NotFound("USER_NOT_FOUND", "User not found")
+	}
+	if userEntity.IsDeleted {
+		tx.Rollback()
+		return nil, domainerror.FailedPrecondition("USER_ALREADY_DISABLED", "User is already disabled")
+	}
+
+	now := time.Now()
+	err = userRepository.DisableUser(ctx, userEntity.Id, now, claims.FullName)
+	if err != nil {
+		return nil, err
+	}
+
+	// access tokens are rejected by the auth middleware, refresh tokens must not outlive a restore
+	err = us.refreshTokenRepository.WithTransaction(tx).RevokeRefreshTokensByUserId(ctx, userEntity.Id, now)
+	if err != nil {
+		return nil, err
+	}
+	err = us.userSessionRepository.WithTransaction(tx).RevokeUserSessionsByUserId(ctx, userEntity.Id, now)
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, err
+	}
+
+	return &user.DisableUserResponse{
+		Base: utils.SuccessResponse("User disabled successfully"),
+	}, nil
+}
+
+func (us *userService) RestoreUser(ctx context.Context, req *user.RestoreUserRequest) (*user.RestoreUserResponse, error) {
+
+	claims, err := jwtentity.GetClaimsFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	userEntity, err := us.userRepository.GetUserById(ctx, req.Id)
+	if err != nil {
+		return nil, err
+	}
+	if userEntity == nil {
+		return nil, domainerror.NotFound("USER_NOT_FOUND", "User not found")
+	}
+	if !userEntity.IsDeleted {
+		return nil, domainerror.FailedPrecondition("USER_NOT_DISABLED", "User is not disabled")
+	}
+
+	err = us.userRepository.RestoreUser(ctx, userEntity.Id, claims.FullName)
+	if err != nil {
+		return nil, err
+	}
+
+	return &user.RestoreUserResponse{
+		Base: utils.SuccessResponse("User restored successfully"),
+	}, nil
+}
+
+func (us *userService) GetActiveUser(ctx context.Context, userId string) (*entity.User, error) {
+
+	userEntity, err := us.userRepository.GetUserById(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+	if userEntity == nil || userEntity.IsDeleted {
+		return nil, nil
+	}
+	return userEntity, nil
+}
+
+func (us *userService) GetActiveSession(ctx context.Context, userId string, sessionId string) (*entity.UserSession, error) {
+
+	userSession, err := us.userSessionRepository.GetUserSessionById(ctx, sessionId)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	if userSession == nil || userSession.UserId != userId || userSession.RevokedAt != nil || !now.Before(userSession.ExpiresAt) {
+		return nil, nil
+	}
+
+	if now.Sub(userSession.LastSeenAt) >= sessionLastSeenInterval {
+		err = us.userSessionRepository.UpdateUserSessionLastSeen(ctx, userSession.Id, now)
+		if err != nil {
+			return nil, err
+		}
+		userSession.LastSeenAt = now
+	}
+	return userSession, nil
+}
+
+func toUserItem(userEntity *entity.User) *user.UserItem {
+	userItem := &user.UserItem{
+		Id:            userEntity.Id,
+		FullName:      userEntity.FullName,
+		Email:         userEntity.Email,
+		RoleCode:      userEntity.RoleCode,
+		EmailVerified: userEntity.EmailVerifiedAt != nil,
+		IsDisabled:    userEntity.IsDeleted,
+		CreatedAt:     timestamppb.New(userEntity.CreatedAt),
+	}
+	if userEntity.DeletedAt != nil {
+		userItem.DisabledAt = timestamppb.New(*userEntity.DeletedAt)
+	}
+	if userEntity.DeletedBy != nil {
+		userItem.DisabledBy = *userEntity.DeletedBy
+	}
+	return userItem
+}
@@ -1,51 +1,183 @@
-package service
-
-import (
-	"context"
-	"errors"
-	"time"
-
-	"github.com/arthurhzna/Golang_gRPC/internal/dto"
-	"github.com/arthurhzna/Golang_gRPC/internal/entity"
-	"github.com/arthurhzna/Golang_gRPC/internal/repository"
-)
-
-type IWebhookService interface {
-	ReceiveInvoice(ctx context.Context, req *dto.XenditInvoiceRequest) error
-}
-
-type webhookService struct {
-	orderRepository repository.IOrderRepository
-}
-
-func NewWebhookService(orderRepository repository.IOrderRepository) IWebhookService {
-	return &webhookService{
-		orderRepository: orderRepository,
-	}
-}
-
-func (ws *webhookService) ReceiveInvoice(ctx context.Context, req *dto.XenditInvoiceRequest) error {
-
-	orderEntity, err := ws.orderRepository.GetOrderById(ctx, req.ExternalID)
-	if err != nil {
-		return err
-	}
-	if orderEntity == nil {
-		return errors.New("order not found")
-	}
-
-	now := time.Now()
-	updatedBy := "System"
-	orderEntity.OrderStatusCode = entity.OrderStatusCodePaid
-	orderEntity.UpdatedAt = &now
-	orderEntity.UpdatedBy = &updatedBy
-	orderEntity.XenditPaidAt = &now
-	orderEntity.XenditPaymentChannel = &req.PaymentChannel
-	orderEntity.XenditPaymentMethod = &req.PaymentMethod
-
-	err = ws.orderRepository.UpdateOrder(ctx, orderEntity)
-	if err != nil {
-		return err
-	}
-	return nil
-}
+package service
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/arthurhzna/Golang_gRPC/internal/entity"
+	"github.com/arthurhzna/Golang_gRPC/internal/payment"
+	"github.com/arthurhzna/Golang_gRPC/internal/pubsub"
+	"github.com/arthurhzna/Golang_gRPC/internal/repository"
+	"github.com/google/uuid"
+)
+
+type IWebhookService interface {
+	ReceiveInvoice(ctx context.Context, header http.Header, body []byte) error
+}
+
+type webhookService struct {
+	db                     *sql.DB
+	orderRepository        repository.IOrderRepository
+	productRepository      repository.IProductRepository
+	webhookEventRepository repository.IWebhookEventRepository
+	paymentGateway         payment.PaymentGateway
+	orderEventBus          pubsub.OrderEventBus
+}
+
+func NewWebhookService(db *sql.DB, orderRepository repository.IOrderRepository, productRepository repository.IProductRepository, webhookEventRepository repository.IWebhookEventRepository, paymentGateway payment.PaymentGateway, orderEventBus pubsub.OrderEventBus) IWebhookService {
+	return &webhookService{
+		db:                     db,
+		orderRepository:        orderRepository,
+		productRepository:      productRepository,
+		webhookEventRepository: webhookEventRepository,
+		paymentGateway:         paymentGateway,
+		orderEventBus:          orderEventBus,
+	}
+}
+
+func (ws *webhookService) ReceiveInvoice(ctx context.Context, header http.Header, body []byte) error {
+
+	callbackEvent, err := ws.paymentGateway.ParseCallback(header, body)
+	if err != nil {
+		return err
+	}
+
+	tx, err := ws.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil && tx != nil {
+			tx.Rollback()
+		}
+	}()
+
+	orderRepository := ws.orderRepository.WithTransaction(tx)
+	productRepository := ws.productRepository.WithTransaction(tx)
+	webhookEventRepository := ws.webhookEventRepository.WithTransaction(tx)
+
+	now := time.Now()
+	event := entity.PaymentWebhookEvent{
+		Id:        uuid.NewString(),
+		Provider:  ws.paymentGateway.Provider(),
+		InvoiceId: callbackEvent.InvoiceId,
+		OrderId:   callbackEvent.OrderId,
+		Status:    callbackEvent.Status,
+		Amount:    callbackEvent.Amount,
+		Payload:   callbackEvent.Payload,
+		Result:    entity.WebhookEventResultReceived,
+		CreatedAt: now,
+	}
+	isNewEvent, err := webhookEventRepository.CreatePaymentWebhookEvent(ctx, &event)
+	if err != nil {
+		return err
+	}
+	if !isNewEvent {
+		// redelivered callback, it was already handled
+		tx.Rollback()
+		return nil
+	}
+
+	result, orderEntity, err := ws.applyInvoiceStatus(ctx, orderRepository, productRepository, callbackEvent, now)
+	if err != nil {
+		return err
+	}
+
+	err = webhookEventRepository.UpdatePaymentWebhookEventResult(ctx, event.Id, result, now)
+	if err != nil {
+		return err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return err
+	}
+	if result == entity.WebhookEventResultProcessed {
+		publishOrderStatusEvent(ctx, ws.orderEventBus, orderEntity)
+	}
+	return nil
+}
+
+func (ws *webhookService) applyInvoiceStatus(ctx context.Context, orderRepository repository.IOrderRepository, productRepository repository.IProductRepository, callbackEvent *payment.CallbackEvent, now time.Time) (string, *entity.Order, error) {
+	provider := ws.paymentGateway.Provider()
+	// order ids are uuids, anything else would fail the query and make the provider retry the callback forever
+	if uuid.Validate(callbackEvent.OrderId) != nil {
+		slog.WarnContext(ctx, "Invoice order id is not valid", "provider", provider, "invoice_id", callbackEvent.InvoiceId, "order_id", callbackEvent.OrderId)
+		return entity.WebhookEventResultRejected, nil, nil
+	}
+	orderEntity, err := orderRepository.GetOrderByIdForUpdate(ctx, callbackEvent.OrderId)
+	if err != nil {
+		return "", nil, err
+	}
+	if orderEntity == nil {
+		slog.WarnContext(ctx, "Invoice order not found", "provider", provider, "invoice_id", callbackEvent.InvoiceId, "order_id", callbackEvent.OrderId)
+		return entity.WebhookEventResultRejected, orderEntity, nil
+	}
+	if orderEntity.PaymentProvider == nil || *orderEntity.PaymentProvider != provider ||
+		orderEntity.PaymentInvoiceId == nil || *orderEntity.PaymentInvoiceId != callbackEvent.InvoiceId {
+		slog.WarnContext(ctx, "Invoice does not belong to the order", "provider", provider, "invoice_id", callbackEvent.InvoiceId, "order_id", orderEntity.Id)
+		return entity.WebhookEventResultRejected, orderEntity, nil
+	}
+
+	switch callbackEvent.Status {
+	case payment.InvoiceStatusPaid, payment.InvoiceStatusSettled:
+		if !entity.CanTransitionOrderStatus(orderEntity.OrderStatusCode, entity.OrderStatusCodePaid, entity.OrderActorSystem) {
+			if orderEntity.OrderStatusCode == entity.OrderStatusCodeExpired || orderEntity.OrderStatusCode == entity.OrderStatusCodeCanceled {
+				slog.ErrorContext(ctx, "Invoice paid for a closed order, needs a manual refund", "provider", provider, "invoice_id", callbackEvent.InvoiceId, "order_id", orderEntity.Id, "status", orderEntity.OrderStatusCode)
+				return entity.WebhookEventResultRejected, orderEntity, nil
+			}
+			return entity.WebhookEventResultIgnored, orderEntity, nil
+		}
+		if !payment.AmountsEqual(callbackEvent.Amount, orderEntity.Total) {
+			slog.WarnContext(ctx, "Invoice amount does not match the order total", "provider", provider, "invoice_id", callbackEvent.InvoiceId, "amount", callbackEvent.Amount, "order_id", orderEntity.Id, "total", orderEntity.Total)
+			return entity.WebhookEventResultRejected, orderEntity, nil
+		}
+
+		paidAt := now
+		if callbackEvent.PaidAt != nil {
+			paidAt = *callbackEvent.PaidAt
+		}
+		orderEntity.PaidAt = &paidAt
+		orderEntity.PaymentChannel = &callbackEvent.PaymentChannel
+		orderEntity.PaymentMethod = &callbackEvent.PaymentMethod
+
+		err = transitionOrderStatus(ctx, orderRepository, orderEntity, orderStatusChange{
+			ToStatusCode: entity.OrderStatusCodePaid,
+			Actor:        "System",
+			ActorRole:    entity.OrderActorSystem,
+			Reason:       fmt.Sprintf("%s invoice %s %s", provider, callbackEvent.InvoiceId, callbackEvent.Status),
+			At:           now,
+		})
+		if err != nil {
+			return "", nil, err
+		}
+		return entity.WebhookEventResultProcessed, orderEntity, nil
+
+	case payment.InvoiceStatusExpired:
+		if !entity.CanTransitionOrderStatus(orderEntity.OrderStatusCode, entity.OrderStatusCodeExpired, entity.OrderActorSystem) {
+			return entity.WebhookEventResultIgnored, orderEntity, nil
+		}
+
+		err = transitionOrderStatus(ctx, orderRepository, orderEntity, orderStatusChange{
+			ToStatusCode: entity.OrderStatusCodeExpired,
+			Actor:        "System",
+			ActorRole:    entity.OrderActorSystem,
+			Reason:       fmt.Sprintf("%s invoice %s %s", provider, callbackEvent.InvoiceId, callbackEvent.Status),
+			At:           now,
+		})
+		if err != nil {
+			return "", nil, err
+		}
+		err = restoreOrderStock(ctx, productRepository, orderEntity)
+		if err != nil {
+			return "", nil, err
+		}
+		return entity.WebhookEventResultProcessed, orderEntity, nil
+	}
+
+	return entity.WebhookEventResultIgnored, orderEntity, nil
+}
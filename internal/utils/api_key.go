@@ -0,0 +1,21 @@
+package utils
+
+import (
+	"context"
+	"strings"
+
+	"google.golang.org/grpc/metadata"
+)
+
+// ApiKeyFromContext returns the x-api-key metadata of the request, or an empty string when it was not sent.
+func ApiKeyFromContext(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+	apiKey := md.Get("x-api-key")
+	if len(apiKey) == 0 {
+		return ""
+	}
+	return strings.TrimSpace(apiKey[0])
+}
@@ -0,0 +1,116 @@
+package utils
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"strings"
+
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+type clientIpContextKey struct{}
+
+// TrustedProxies are the addresses allowed to tell the client address in x-forwarded-for.
+// A header sent by anyone else is ignored, otherwise a client could pick the address it is rate limited and logged by.
+type TrustedProxies struct {
+	networks []*net.IPNet
+}
+
+// NewTrustedProxiesFromEnv reads TRUSTED_PROXIES, a comma separated list of IPs or CIDRs. None are trusted when it is not set.
+func NewTrustedProxiesFromEnv() (*TrustedProxies, error) {
+	trustedProxies := &TrustedProxies{}
+	for _, value := range strings.Split(os.Getenv("TRUSTED_PROXIES"), ",") {
+		value = strings.TrimSpace(value)
+		if value == "" {
+			continue
+		}
+		if !strings.Contains(value, "/") {
+			ip := net.ParseIP(value)
+			if ip == nil {
+				return nil, fmt.Errorf("invalid trusted proxy %q", value)
+			}
+			bits := 8 * net.IPv6len
+			if ip.To4() != nil {
+				ip = ip.To4()
+				bits = 8 * net.IPv4len
+			}
+			trustedProxies.networks = append(trustedProxies.networks, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+			continue
+		}
+		_, network, err := net.ParseCIDR(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid trusted proxy %q: %w", value, err)
+		}
+		trustedProxies.networks = append(trustedProxies.networks, network)
+	}
+	return trustedProxies, nil
+}
+
+func (tp *TrustedProxies) isTrusted(address string) bool {
+	ip := net.ParseIP(address)
+	if ip == nil {
+		return false
+	}
+	for _, network := range tp.networks {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// ClientIp returns the gRPC peer address, or when the peer is a trusted proxy the x-forwarded-for address
+// closest to it that is not a trusted proxy itself. Addresses further left were written by the client and are ignored.
+func (tp *TrustedProxies) ClientIp(ctx context.Context) string {
+	clientIp := peerIpFromContext(ctx)
+	if clientIp == "" || !tp.isTrusted(clientIp) {
+		return clientIp
+	}
+
+	md, _ := metadata.FromIncomingContext(ctx)
+	hops := make([]string, 0)
+	for _, value := range md.Get("x-forwarded-for") {
+		for _, hop := range strings.Split(value, ",") {
+			hops = append(hops, strings.TrimSpace(hop))
+		}
+	}
+	for i := len(hops) - 1; i >= 0; i-- {
+		if net.ParseIP(hops[i]) == nil {
+			break
+		}
+		clientIp = hops[i]
+		if !tp.isTrusted(clientIp) {
+			break
+		}
+	}
+	return clientIp
+}
+
+func WithClientIp(ctx context.Context, clientIp string) context.Context {
+	return context.WithValue(ctx, clientIpContextKey{}, clientIp)
+}
+
+// ClientIpFromContext returns the client address resolved for the request, or the address of the gRPC peer
+// when it was not resolved. It returns an empty string when neither is known.
+func ClientIpFromContext(ctx context.Context) string {
+	clientIp, ok := ctx.Value(clientIpContextKey{}).(string)
+	if ok {
+		return clientIp
+	}
+	return peerIpFromContext(ctx)
+}
+
+func peerIpFromContext(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return ""
+	}
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		return p.Addr.String()
+	}
+	return host
+}
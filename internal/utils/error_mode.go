@@ -0,0 +1,18 @@
+package utils
+
+import (
+	"context"
+
+	"google.golang.org/grpc/metadata"
+)
+
+// BaseResponseErrorsFromContext reports whether the client sent x-error-mode: base-response,
+// asking for domain errors inside the BaseResponse of an OK response instead of a gRPC status.
+func BaseResponseErrorsFromContext(ctx context.Context) bool {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return false
+	}
+	errorMode := md.Get("x-error-mode")
+	return len(errorMode) > 0 && errorMode[0] == "base-response"
+}
@@ -1,44 +1,56 @@
-package utils
-
-import (
-	"github.com/arthurhzna/Golang_gRPC/pb/common"
-	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/status"
-)
-
-func SuccessResponse(message string) *common.BaseResponse {
-	return &common.BaseResponse{
-		StatusCode: 200,
-		Message:    message,
-		IsError:    false,
-	}
-}
-
-func ValidationErrorResponse(validationErrors []*common.ValidateError) *common.BaseResponse {
-	return &common.BaseResponse{
-		StatusCode:     400,
-		Message:        "Validation errors",
-		IsError:        true,
-		ValidateErrors: validationErrors,
-	}
-}
-
-func BadRequestResponse(message string) *common.BaseResponse {
-	return &common.BaseResponse{
-		StatusCode: 400,
-		Message:    message,
-		IsError:    true,
-	}
-}
-
-func NotFoundResponse(message string) *common.BaseResponse {
-	return &common.BaseResponse{
-		StatusCode: 404,
-		Message:    message,
-		IsError:    true,
-	}
-}
-
-func UnaunthorizedResponse() error {
-	return status.Errorf(codes.Unauthenticated, "Unauthorized")
-}
+package utils
+
+import (
+	"github.com/arthurhzna/Golang_gRPC/internal/domainerror"
+	"github.com/arthurhzna/Golang_gRPC/pb/common"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func SuccessResponse(message string) *common.BaseResponse {
+	return &common.BaseResponse{
+		StatusCode: 200,
+		Message:    message,
+		IsError:    false,
+	}
+}
+
+func BadRequestResponse(message string) *common.BaseResponse {
+	return &common.BaseResponse{
+		StatusCode: 400,
+		Message:    message,
+		IsError:    true,
+	}
+}
+
+func NotFoundResponse(message string) *common.BaseResponse {
+	return &common.BaseResponse{
+		StatusCode: 404,
+		Message:    message,
+		IsError:    true,
+	}
+}
+
+func UnaunthorizedResponse() error {
+	return status.Errorf(codes.Unauthenticated, "Unauthorized")
+}
+
+// DomainErrorResponse is the BaseResponse a domain error had before errors were sent as gRPC status,
+// not found answers 404 and every other kind 400 with the field violations as validation errors.
+func DomainErrorResponse(domainErr *domainerror.Error) *common.BaseResponse {
+	var baseResponse *common.BaseResponse
+	switch domainErr.Kind {
+	case domainerror.KindNotFound:
+		baseResponse = NotFoundResponse(domainErr.Message)
+	default:
+		baseResponse = BadRequestResponse(domainErr.Message)
+	}
+	for _, fieldViolation := range domainErr.FieldViolations {
+		baseResponse.ValidateErrors = append(baseResponse.ValidateErrors, &common.ValidateError{
+			Field:   fieldViolation.Field,
+			Message: fieldViolation.Description,
+			RuleId:  fieldViolation.Reason,
+		})
+	}
+	return baseResponse
+}
@@ -0,0 +1,34 @@
+package utils
+
+import (
+	"context"
+	"strings"
+	"unicode/utf8"
+
+	"google.golang.org/grpc/metadata"
+)
+
+// userAgentMaxLength keeps a client from filling the session table with a huge header
+const userAgentMaxLength = 255
+
+// UserAgentFromContext returns the user-agent metadata of the request as valid UTF-8, cut at a character boundary to at most
+// 255 bytes, or an empty string when it was not sent. Invalid bytes would make the database reject the session insert.
+func UserAgentFromContext(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+	userAgent := md.Get("user-agent")
+	if len(userAgent) == 0 {
+		return ""
+	}
+	validUserAgent := strings.ToValidUTF8(userAgent[0], "")
+	if len(validUserAgent) <= userAgentMaxLength {
+		return validUserAgent
+	}
+	cut := userAgentMaxLength
+	for cut > 0 && !utf8.RuneStart(validUserAgent[cut]) {
+		cut--
+	}
+	return validUserAgent[:cut]
+}
@@ -1,27 +1,34 @@
-package utils
-
-import (
-	"errors"
-
-	"buf.build/go/protovalidate"
-	"github.com/arthurhzna/Golang_gRPC/pb/common"
-	"google.golang.org/protobuf/proto"
-)
-
-func CheckValidation(req proto.Message) ([]*common.ValidateError, error) {
-	if err := protovalidate.Validate(req); err != nil {
-		var validationError *protovalidate.ValidationError
-		if errors.As(err, &validationError) {
-			var validationErrorsResponse []*common.ValidateError = make([]*common.ValidateError, 0) // array that will store the validation errors
-			for _, violation := range validationError.Violations {
-				validationErrorsResponse = append(validationErrorsResponse, &common.ValidateError{
-					Field:   *violation.Proto.Field.Elements[0].FieldName,
-					Message: *violation.Proto.Message,
-				})
-			}
-			return validationErrorsResponse, nil
-		}
-		return nil, err
-	}
-	return nil, nil
-}
+package utils
+
+import (
+	"errors"
+
+	"buf.build/go/protovalidate"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/arthurhzna/Golang_gRPC/internal/domainerror"
+)
+
+// ValidateRequest runs the protovalidate rules of req. A failed validation is returned as an InvalidArgument domain error
+// with one violation per broken rule, naming the full field path such as products[2].quantity and the rule id.
+func ValidateRequest(req proto.Message) error {
+	err := protovalidate.Validate(req)
+	if err == nil {
+		return nil
+	}
+
+	var validationError *protovalidate.ValidationError
+	if !errors.As(err, &validationError) {
+		return err
+	}
+
+	domainErr := domainerror.InvalidArgument("VALIDATION_FAILED", "Validation errors")
+	for _, violation := range validationError.Violations {
+		domainErr.FieldViolations = append(domainErr.FieldViolations, domainerror.FieldViolation{
+			Field:       protovalidate.FieldPathString(violation.Proto.GetField()),
+			Description: violation.Proto.GetMessage(),
+			Reason:      violation.Proto.GetRuleId(),
+		})
+	}
+	return domainErr
+}
@@ -0,0 +1,53 @@
+package worker
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/arthurhzna/Golang_gRPC/internal/repository"
+)
+
+type loginAttemptPruneWorker struct {
+	loginAttemptRepository repository.ILoginAttemptRepository
+	retention              time.Duration
+	interval               time.Duration
+}
+
+func NewLoginAttemptPruneWorker(loginAttemptRepository repository.ILoginAttemptRepository, retention time.Duration, interval time.Duration) *loginAttemptPruneWorker {
+	return &loginAttemptPruneWorker{
+		loginAttemptRepository: loginAttemptRepository,
+		retention:              retention,
+		interval:               interval,
+	}
+}
+
+// Start removes login attempt counters whose last failure is older than retention every interval until ctx is canceled.
+func (lw *loginAttemptPruneWorker) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(lw.interval)
+		defer ticker.Stop()
+
+		for {
+			lw.run(ctx)
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+func (lw *loginAttemptPruneWorker) run(ctx context.Context) {
+	now := time.Now()
+	prunedCount, err := lw.loginAttemptRepository.PruneLoginAttempts(ctx, now.Add(-lw.retention), now)
+	if err != nil {
+		slog.ErrorContext(ctx, "Login attempt prune worker failed", "error", err)
+		return
+	}
+	if prunedCount > 0 {
+		slog.InfoContext(ctx, "Login attempt prune worker pruned entries", "count", prunedCount)
+	}
+}
@@ -0,0 +1,50 @@
+package worker
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/arthurhzna/Golang_gRPC/internal/service"
+)
+
+type orderExpiryWorker struct {
+	orderExpiryService service.IOrderExpiryService
+	interval           time.Duration
+}
+
+func NewOrderExpiryWorker(orderExpiryService service.IOrderExpiryService, interval time.Duration) *orderExpiryWorker {
+	return &orderExpiryWorker{
+		orderExpiryService: orderExpiryService,
+		interval:           interval,
+	}
+}
+
+// Start runs the expiry job in the background every interval until ctx is canceled.
+func (ow *orderExpiryWorker) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(ow.interval)
+		defer ticker.Stop()
+
+		for {
+			ow.run(ctx)
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+func (ow *orderExpiryWorker) run(ctx context.Context) {
+	expiredCount, err := ow.orderExpiryService.ExpireUnpaidOrders(ctx)
+	if err != nil {
+		slog.ErrorContext(ctx, "Order expiry worker failed", "error", err)
+		return
+	}
+	if expiredCount > 0 {
+		slog.InfoContext(ctx, "Order expiry worker expired orders", "count", expiredCount)
+	}
+}
@@ -0,0 +1,50 @@
+package worker
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/arthurhzna/Golang_gRPC/internal/revocation"
+)
+
+type tokenRevocationPruneWorker struct {
+	tokenRevocationStore revocation.TokenRevocationStore
+	interval             time.Duration
+}
+
+func NewTokenRevocationPruneWorker(tokenRevocationStore revocation.TokenRevocationStore, interval time.Duration) *tokenRevocationPruneWorker {
+	return &tokenRevocationPruneWorker{
+		tokenRevocationStore: tokenRevocationStore,
+		interval:             interval,
+	}
+}
+
+// Start removes revocations of already expired tokens every interval until ctx is canceled.
+func (tw *tokenRevocationPruneWorker) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(tw.interval)
+		defer ticker.Stop()
+
+		for {
+			tw.run(ctx)
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+func (tw *tokenRevocationPruneWorker) run(ctx context.Context) {
+	prunedCount, err := tw.tokenRevocationStore.PruneExpired(ctx, time.Now())
+	if err != nil {
+		slog.ErrorContext(ctx, "Token revocation prune worker failed", "error", err)
+		return
+	}
+	if prunedCount > 0 {
+		slog.InfoContext(ctx, "Token revocation prune worker pruned entries", "count", prunedCount)
+	}
+}
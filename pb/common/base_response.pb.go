@@ -22,9 +22,12 @@ const (
 )
 
 type ValidateError struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Field         string                 `protobuf:"bytes,1,opt,name=field,proto3" json:"field,omitempty"`
-	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// field is the full path of the field, such as products[2].quantity
+	Field   string `protobuf:"bytes,1,opt,name=field,proto3" json:"field,omitempty"`
+	Message string `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	// rule_id is the id of the broken protovalidate rule, such as int64.gt
+	RuleId        string `protobuf:"bytes,3,opt,name=rule_id,json=ruleId,proto3" json:"rule_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -73,6 +76,13 @@ func (x *ValidateError) GetMessage() string {
 	return ""
 }
 
+func (x *ValidateError) GetRuleId() string {
+	if x != nil {
+		return x.RuleId
+	}
+	return ""
+}
+
 type BaseResponse struct {
 	state          protoimpl.MessageState `protogen:"open.v1"`
 	StatusCode     int64                  `protobuf:"varint,1,opt,name=status_code,json=statusCode,proto3" json:"status_code,omitempty"`
@@ -145,10 +155,11 @@ var File_common_base_response_proto protoreflect.FileDescriptor
 
 const file_common_base_response_proto_rawDesc = "" +
 	"\n" +
-	"\x1acommon/base_response.proto\x12\x06common\"?\n" +
+	"\x1acommon/base_response.proto\x12\x06common\"X\n" +
 	"\rValidateError\x12\x14\n" +
 	"\x05field\x18\x01 \x01(\tR\x05field\x12\x18\n" +
-	"\amessage\x18\x02 \x01(\tR\amessage\"\xa4\x01\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12\x17\n" +
+	"\arule_id\x18\x03 \x01(\tR\x06ruleId\"\xa4\x01\n" +
 	"\fBaseResponse\x12\x1f\n" +
 	"\vstatus_code\x18\x01 \x01(\x03R\n" +
 	"statusCode\x12\x18\n" +
@@ -204,6 +204,135 @@ func (x *CreateOrderResponse) GetId() string {
 	return ""
 }
 
+// cart_ids empty checks out the whole cart
+type CheckoutCartRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FullName      string                 `protobuf:"bytes,1,opt,name=full_name,json=fullName,proto3" json:"full_name,omitempty"`
+	Address       string                 `protobuf:"bytes,2,opt,name=address,proto3" json:"address,omitempty"`
+	PhoneNumber   string                 `protobuf:"bytes,3,opt,name=phone_number,json=phoneNumber,proto3" json:"phone_number,omitempty"`
+	Notes         string                 `protobuf:"bytes,4,opt,name=notes,proto3" json:"notes,omitempty"`
+	CartIds       []string               `protobuf:"bytes,5,rep,name=cart_ids,json=cartIds,proto3" json:"cart_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CheckoutCartRequest) Reset() {
+	*x = CheckoutCartRequest{}
+	mi := &file_order_order_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckoutCartRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckoutCartRequest) ProtoMessage() {}
+
+func (x *CheckoutCartRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_order_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckoutCartRequest.ProtoReflect.Descriptor instead.
+func (*CheckoutCartRequest) Descriptor() ([]byte, []int) {
+	return file_order_order_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *CheckoutCartRequest) GetFullName() string {
+	if x != nil {
+		return x.FullName
+	}
+	return ""
+}
+
+func (x *CheckoutCartRequest) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *CheckoutCartRequest) GetPhoneNumber() string {
+	if x != nil {
+		return x.PhoneNumber
+	}
+	return ""
+}
+
+func (x *CheckoutCartRequest) GetNotes() string {
+	if x != nil {
+		return x.Notes
+	}
+	return ""
+}
+
+func (x *CheckoutCartRequest) GetCartIds() []string {
+	if x != nil {
+		return x.CartIds
+	}
+	return nil
+}
+
+type CheckoutCartResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Base          *common.BaseResponse   `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
+	Id            string                 `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CheckoutCartResponse) Reset() {
+	*x = CheckoutCartResponse{}
+	mi := &file_order_order_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckoutCartResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckoutCartResponse) ProtoMessage() {}
+
+func (x *CheckoutCartResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_order_order_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckoutCartResponse.ProtoReflect.Descriptor instead.
+func (*CheckoutCartResponse) Descriptor() ([]byte, []int) {
+	return file_order_order_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *CheckoutCartResponse) GetBase() *common.BaseResponse {
+	if x != nil {
+		return x.Base
+	}
+	return nil
+}
+
+func (x *CheckoutCartResponse) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
 type ListOrderAdminRequest struct {
 	state         protoimpl.MessageState    `protogen:"open.v1"`
 	Pagination    *common.PaginationRequest `protobuf:"bytes,1,opt,name=pagination,proto3" json:"pagination,omitempty"`
@@ -213,7 +342,7 @@ type ListOrderAdminRequest struct {
 
 func (x *ListOrderAdminRequest) Reset() {
 	*x = ListOrderAdminRequest{}
-	mi := &file_order_order_proto_msgTypes[3]
+	mi := &file_order_order_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -225,7 +354,7 @@ func (x *ListOrderAdminRequest) String() string {
 func (*ListOrderAdminRequest) ProtoMessage() {}
 
 func (x *ListOrderAdminRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_order_order_proto_msgTypes[3]
+	mi := &file_order_order_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -238,7 +367,7 @@ func (x *ListOrderAdminRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListOrderAdminRequest.ProtoReflect.Descriptor instead.
 func (*ListOrderAdminRequest) Descriptor() ([]byte, []int) {
-	return file_order_order_proto_rawDescGZIP(), []int{3}
+	return file_order_order_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *ListOrderAdminRequest) GetPagination() *common.PaginationRequest {
@@ -260,7 +389,7 @@ type ListOrderAdminResponseItemProduct struct {
 
 func (x *ListOrderAdminResponseItemProduct) Reset() {
 	*x = ListOrderAdminResponseItemProduct{}
-	mi := &file_order_order_proto_msgTypes[4]
+	mi := &file_order_order_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -272,7 +401,7 @@ func (x *ListOrderAdminResponseItemProduct) String() string {
 func (*ListOrderAdminResponseItemProduct) ProtoMessage() {}
 
 func (x *ListOrderAdminResponseItemProduct) ProtoReflect() protoreflect.Message {
-	mi := &file_order_order_proto_msgTypes[4]
+	mi := &file_order_order_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -285,7 +414,7 @@ func (x *ListOrderAdminResponseItemProduct) ProtoReflect() protoreflect.Message
 
 // Deprecated: Use ListOrderAdminResponseItemProduct.ProtoReflect.Descriptor instead.
 func (*ListOrderAdminResponseItemProduct) Descriptor() ([]byte, []int) {
-	return file_order_order_proto_rawDescGZIP(), []int{4}
+	return file_order_order_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *ListOrderAdminResponseItemProduct) GetId() string {
@@ -331,7 +460,7 @@ type ListOrderAdminResponseItem struct {
 
 func (x *ListOrderAdminResponseItem) Reset() {
 	*x = ListOrderAdminResponseItem{}
-	mi := &file_order_order_proto_msgTypes[5]
+	mi := &file_order_order_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -343,7 +472,7 @@ func (x *ListOrderAdminResponseItem) String() string {
 func (*ListOrderAdminResponseItem) ProtoMessage() {}
 
 func (x *ListOrderAdminResponseItem) ProtoReflect() protoreflect.Message {
-	mi := &file_order_order_proto_msgTypes[5]
+	mi := &file_order_order_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -356,7 +485,7 @@ func (x *ListOrderAdminResponseItem) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListOrderAdminResponseItem.ProtoReflect.Descriptor instead.
 func (*ListOrderAdminResponseItem) Descriptor() ([]byte, []int) {
-	return file_order_order_proto_rawDescGZIP(), []int{5}
+	return file_order_order_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *ListOrderAdminResponseItem) GetId() string {
@@ -419,7 +548,7 @@ type ListOrderAdminResponse struct {
 
 func (x *ListOrderAdminResponse) Reset() {
 	*x = ListOrderAdminResponse{}
-	mi := &file_order_order_proto_msgTypes[6]
+	mi := &file_order_order_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -431,7 +560,7 @@ func (x *ListOrderAdminResponse) String() string {
 func (*ListOrderAdminResponse) ProtoMessage() {}
 
 func (x *ListOrderAdminResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_order_order_proto_msgTypes[6]
+	mi := &file_order_order_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -444,7 +573,7 @@ func (x *ListOrderAdminResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListOrderAdminResponse.ProtoReflect.Descriptor instead.
 func (*ListOrderAdminResponse) Descriptor() ([]byte, []int) {
-	return file_order_order_proto_rawDescGZIP(), []int{6}
+	return file_order_order_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *ListOrderAdminResponse) GetBase() *common.BaseResponse {
@@ -477,7 +606,7 @@ type ListOrderRequest struct {
 
 func (x *ListOrderRequest) Reset() {
 	*x = ListOrderRequest{}
-	mi := &file_order_order_proto_msgTypes[7]
+	mi := &file_order_order_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -489,7 +618,7 @@ func (x *ListOrderRequest) String() string {
 func (*ListOrderRequest) ProtoMessage() {}
 
 func (x *ListOrderRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_order_order_proto_msgTypes[7]
+	mi := &file_order_order_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -502,7 +631,7 @@ func (x *ListOrderRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListOrderRequest.ProtoReflect.Descriptor instead.
 func (*ListOrderRequest) Descriptor() ([]byte, []int) {
-	return file_order_order_proto_rawDescGZIP(), []int{7}
+	return file_order_order_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *ListOrderRequest) GetPagination() *common.PaginationRequest {
@@ -524,7 +653,7 @@ type ListOrderResponseItemProduct struct {
 
 func (x *ListOrderResponseItemProduct) Reset() {
 	*x = ListOrderResponseItemProduct{}
-	mi := &file_order_order_proto_msgTypes[8]
+	mi := &file_order_order_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -536,7 +665,7 @@ func (x *ListOrderResponseItemProduct) String() string {
 func (*ListOrderResponseItemProduct) ProtoMessage() {}
 
 func (x *ListOrderResponseItemProduct) ProtoReflect() protoreflect.Message {
-	mi := &file_order_order_proto_msgTypes[8]
+	mi := &file_order_order_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -549,7 +678,7 @@ func (x *ListOrderResponseItemProduct) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListOrderResponseItemProduct.ProtoReflect.Descriptor instead.
 func (*ListOrderResponseItemProduct) Descriptor() ([]byte, []int) {
-	return file_order_order_proto_rawDescGZIP(), []int{8}
+	return file_order_order_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *ListOrderResponseItemProduct) GetId() string {
@@ -581,22 +710,22 @@ func (x *ListOrderResponseItemProduct) GetQuantity() int64 {
 }
 
 type ListOrderResponseItem struct {
-	state            protoimpl.MessageState          `protogen:"open.v1"`
-	Id               string                          `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Number           string                          `protobuf:"bytes,2,opt,name=number,proto3" json:"number,omitempty"`
-	Customer         string                          `protobuf:"bytes,3,opt,name=customer,proto3" json:"customer,omitempty"`
-	StatusCode       string                          `protobuf:"bytes,4,opt,name=status_code,json=statusCode,proto3" json:"status_code,omitempty"`
-	Total            float64                         `protobuf:"fixed64,5,opt,name=total,proto3" json:"total,omitempty"`
-	CreatedAt        *timestamppb.Timestamp          `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	Products         []*ListOrderResponseItemProduct `protobuf:"bytes,7,rep,name=products,proto3" json:"products,omitempty"`
-	XenditInvoiceUrl string                          `protobuf:"bytes,8,opt,name=xendit_invoice_url,json=xenditInvoiceUrl,proto3" json:"xendit_invoice_url,omitempty"`
-	unknownFields    protoimpl.UnknownFields
-	sizeCache        protoimpl.SizeCache
+	state             protoimpl.MessageState          `protogen:"open.v1"`
+	Id                string                          `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Number            string                          `protobuf:"bytes,2,opt,name=number,proto3" json:"number,omitempty"`
+	Customer          string                          `protobuf:"bytes,3,opt,name=customer,proto3" json:"customer,omitempty"`
+	StatusCode        string                          `protobuf:"bytes,4,opt,name=status_code,json=statusCode,proto3" json:"status_code,omitempty"`
+	Total             float64                         `protobuf:"fixed64,5,opt,name=total,proto3" json:"total,omitempty"`
+	CreatedAt         *timestamppb.Timestamp          `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	Products          []*ListOrderResponseItemProduct `protobuf:"bytes,7,rep,name=products,proto3" json:"products,omitempty"`
+	PaymentInvoiceUrl string                          `protobuf:"bytes,8,opt,name=payment_invoice_url,json=paymentInvoiceUrl,proto3" json:"payment_invoice_url,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
 }
 
 func (x *ListOrderResponseItem) Reset() {
 	*x = ListOrderResponseItem{}
-	mi := &file_order_order_proto_msgTypes[9]
+	mi := &file_order_order_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -608,7 +737,7 @@ func (x *ListOrderResponseItem) String() string {
 func (*ListOrderResponseItem) ProtoMessage() {}
 
 func (x *ListOrderResponseItem) ProtoReflect() protoreflect.Message {
-	mi := &file_order_order_proto_msgTypes[9]
+	mi := &file_order_order_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -621,7 +750,7 @@ func (x *ListOrderResponseItem) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListOrderResponseItem.ProtoReflect.Descriptor instead.
 func (*ListOrderResponseItem) Descriptor() ([]byte, []int) {
-	return file_order_order_proto_rawDescGZIP(), []int{9}
+	return file_order_order_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *ListOrderResponseItem) GetId() string {
@@ -673,9 +802,9 @@ func (x *ListOrderResponseItem) GetProducts() []*ListOrderResponseItemProduct {
 	return nil
 }
 
-func (x *ListOrderResponseItem) GetXenditInvoiceUrl() string {
+func (x *ListOrderResponseItem) GetPaymentInvoiceUrl() string {
 	if x != nil {
-		return x.XenditInvoiceUrl
+		return x.PaymentInvoiceUrl
 	}
 	return ""
 }
@@ -691,7 +820,7 @@ type ListOrderResponse struct {
 
 func (x *ListOrderResponse) Reset() {
 	*x = ListOrderResponse{}
-	mi := &file_order_order_proto_msgTypes[10]
+	mi := &file_order_order_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -703,7 +832,7 @@ func (x *ListOrderResponse) String() string {
 func (*ListOrderResponse) ProtoMessage() {}
 
 func (x *ListOrderResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_order_order_proto_msgTypes[10]
+	mi := &file_order_order_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -716,7 +845,7 @@ func (x *ListOrderResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListOrderResponse.ProtoReflect.Descriptor instead.
 func (*ListOrderResponse) Descriptor() ([]byte, []int) {
-	return file_order_order_proto_rawDescGZIP(), []int{10}
+	return file_order_order_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *ListOrderResponse) GetBase() *common.BaseResponse {
@@ -749,7 +878,7 @@ type DetailOrderRequest struct {
 
 func (x *DetailOrderRequest) Reset() {
 	*x = DetailOrderRequest{}
-	mi := &file_order_order_proto_msgTypes[11]
+	mi := &file_order_order_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -761,7 +890,7 @@ func (x *DetailOrderRequest) String() string {
 func (*DetailOrderRequest) ProtoMessage() {}
 
 func (x *DetailOrderRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_order_order_proto_msgTypes[11]
+	mi := &file_order_order_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -774,7 +903,7 @@ func (x *DetailOrderRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DetailOrderRequest.ProtoReflect.Descriptor instead.
 func (*DetailOrderRequest) Descriptor() ([]byte, []int) {
-	return file_order_order_proto_rawDescGZIP(), []int{11}
+	return file_order_order_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *DetailOrderRequest) GetId() string {
@@ -785,18 +914,19 @@ func (x *DetailOrderRequest) GetId() string {
 }
 
 type DetailOrderResponseItem struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	Price         float64                `protobuf:"fixed64,3,opt,name=price,proto3" json:"price,omitempty"`
-	Quantity      int64                  `protobuf:"varint,4,opt,name=quantity,proto3" json:"quantity,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Id               string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name             string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Price            float64                `protobuf:"fixed64,3,opt,name=price,proto3" json:"price,omitempty"`
+	Quantity         int64                  `protobuf:"varint,4,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	RefundedQuantity int64                  `protobuf:"varint,5,opt,name=refunded_quantity,json=refundedQuantity,proto3" json:"refunded_quantity,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
 }
 
 func (x *DetailOrderResponseItem) Reset() {
 	*x = DetailOrderResponseItem{}
-	mi := &file_order_order_proto_msgTypes[12]
+	mi := &file_order_order_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -808,7 +938,7 @@ func (x *DetailOrderResponseItem) String() string {
 func (*DetailOrderResponseItem) ProtoMessage() {}
 
 func (x *DetailOrderResponseItem) ProtoReflect() protoreflect.Message {
-	mi := &file_order_order_proto_msgTypes[12]
+	mi := &file_order_order_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -821,7 +951,7 @@ func (x *DetailOrderResponseItem) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DetailOrderResponseItem.ProtoReflect.Descriptor instead.
 func (*DetailOrderResponseItem) Descriptor() ([]byte, []int) {
-	return file_order_order_proto_rawDescGZIP(), []int{12}
+	return file_order_order_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *DetailOrderResponseItem) GetId() string {
@@ -852,28 +982,36 @@ func (x *DetailOrderResponseItem) GetQuantity() int64 {
 	return 0
 }
 
+func (x *DetailOrderResponseItem) GetRefundedQuantity() int64 {
+	if x != nil {
+		return x.RefundedQuantity
+	}
+	return 0
+}
+
 type DetailOrderResponse struct {
-	state            protoimpl.MessageState     `protogen:"open.v1"`
-	Base             *common.BaseResponse       `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
-	Id               string                     `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
-	Number           string                     `protobuf:"bytes,3,opt,name=number,proto3" json:"number,omitempty"`
-	UserFullName     string                     `protobuf:"bytes,4,opt,name=user_full_name,json=userFullName,proto3" json:"user_full_name,omitempty"`
-	Address          string                     `protobuf:"bytes,5,opt,name=address,proto3" json:"address,omitempty"`
-	PhoneNumber      string                     `protobuf:"bytes,6,opt,name=phone_number,json=phoneNumber,proto3" json:"phone_number,omitempty"`
-	Notes            string                     `protobuf:"bytes,7,opt,name=notes,proto3" json:"notes,omitempty"`
-	OrderStatusCode  string                     `protobuf:"bytes,8,opt,name=order_status_code,json=orderStatusCode,proto3" json:"order_status_code,omitempty"`
-	CreatedAt        *timestamppb.Timestamp     `protobuf:"bytes,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	XenditInvoiceUrl string                     `protobuf:"bytes,10,opt,name=xendit_invoice_url,json=xenditInvoiceUrl,proto3" json:"xendit_invoice_url,omitempty"`
-	Items            []*DetailOrderResponseItem `protobuf:"bytes,11,rep,name=items,proto3" json:"items,omitempty"`
-	Total            float64                    `protobuf:"fixed64,12,opt,name=total,proto3" json:"total,omitempty"`
-	ExpiredAt        *timestamppb.Timestamp     `protobuf:"bytes,13,opt,name=expired_at,json=expiredAt,proto3" json:"expired_at,omitempty"`
-	unknownFields    protoimpl.UnknownFields
-	sizeCache        protoimpl.SizeCache
+	state             protoimpl.MessageState     `protogen:"open.v1"`
+	Base              *common.BaseResponse       `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
+	Id                string                     `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	Number            string                     `protobuf:"bytes,3,opt,name=number,proto3" json:"number,omitempty"`
+	UserFullName      string                     `protobuf:"bytes,4,opt,name=user_full_name,json=userFullName,proto3" json:"user_full_name,omitempty"`
+	Address           string                     `protobuf:"bytes,5,opt,name=address,proto3" json:"address,omitempty"`
+	PhoneNumber       string                     `protobuf:"bytes,6,opt,name=phone_number,json=phoneNumber,proto3" json:"phone_number,omitempty"`
+	Notes             string                     `protobuf:"bytes,7,opt,name=notes,proto3" json:"notes,omitempty"`
+	OrderStatusCode   string                     `protobuf:"bytes,8,opt,name=order_status_code,json=orderStatusCode,proto3" json:"order_status_code,omitempty"`
+	CreatedAt         *timestamppb.Timestamp     `protobuf:"bytes,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	PaymentInvoiceUrl string                     `protobuf:"bytes,10,opt,name=payment_invoice_url,json=paymentInvoiceUrl,proto3" json:"payment_invoice_url,omitempty"`
+	Items             []*DetailOrderResponseItem `protobuf:"bytes,11,rep,name=items,proto3" json:"items,omitempty"`
+	Total             float64                    `protobuf:"fixed64,12,opt,name=total,proto3" json:"total,omitempty"`
+	ExpiredAt         *timestamppb.Timestamp     `protobuf:"bytes,13,opt,name=expired_at,json=expiredAt,proto3" json:"expired_at,omitempty"`
+	RefundedTotal     float64                    `protobuf:"fixed64,14,opt,name=refunded_total,json=refundedTotal,proto3" json:"refunded_total,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
 }
 
 func (x *DetailOrderResponse) Reset() {
 	*x = DetailOrderResponse{}
-	mi := &file_order_order_proto_msgTypes[13]
+	mi := &file_order_order_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -885,7 +1023,7 @@ func (x *DetailOrderResponse) String() string {
 func (*DetailOrderResponse) ProtoMessage() {}
 
 func (x *DetailOrderResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_order_order_proto_msgTypes[13]
+	mi := &file_order_order_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -898,7 +1036,7 @@ func (x *DetailOrderResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DetailOrderResponse.ProtoReflect.Descriptor instead.
 func (*DetailOrderResponse) Descriptor() ([]byte, []int) {
-	return file_order_order_proto_rawDescGZIP(), []int{13}
+	return file_order_order_proto_rawDescGZIP(), []int{15}
 }
 
 func (x *DetailOrderResponse) GetBase() *common.BaseResponse {
@@ -964,9 +1102,9 @@ func (x *DetailOrderResponse) GetCreatedAt() *timestamppb.Timestamp {
 	return nil
 }
 
-func (x *DetailOrderResponse) GetXenditInvoiceUrl() string {
+func (x *DetailOrderResponse) GetPaymentInvoiceUrl() string {
 	if x != nil {
-		return x.XenditInvoiceUrl
+		return x.PaymentInvoiceUrl
 	}
 	return ""
 }
@@ -992,17 +1130,25 @@ func (x *DetailOrderResponse) GetExpiredAt() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *DetailOrderResponse) GetRefundedTotal() float64 {
+	if x != nil {
+		return x.RefundedTotal
+	}
+	return 0
+}
+
 type UpdateOrderStatusRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	OrderId       string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
 	NewStatusCode string                 `protobuf:"bytes,2,opt,name=new_status_code,json=newStatusCode,proto3" json:"new_status_code,omitempty"`
+	Reason        string                 `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *UpdateOrderStatusRequest) Reset() {
 	*x = UpdateOrderStatusRequest{}
-	mi := &file_order_order_proto_msgTypes[14]
+	mi := &file_order_order_proto_msgTypes[16]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1014,7 +1160,7 @@ func (x *UpdateOrderStatusRequest) String() string {
 func (*UpdateOrderStatusRequest) ProtoMessage() {}
 
 func (x *UpdateOrderStatusRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_order_order_proto_msgTypes[14]
+	mi := &file_order_order_proto_msgTypes[16]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1027,7 +1173,7 @@ func (x *UpdateOrderStatusRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateOrderStatusRequest.ProtoReflect.Descriptor instead.
 func (*UpdateOrderStatusRequest) Descriptor() ([]byte, []int) {
-	return file_order_order_proto_rawDescGZIP(), []int{14}
+	return file_order_order_proto_rawDescGZIP(), []int{16}
 }
 
 func (x *UpdateOrderStatusRequest) GetOrderId() string {
@@ -1044,6 +1190,13 @@ func (x *UpdateOrderStatusRequest) GetNewStatusCode() string {
 	return ""
 }
 
+func (x *UpdateOrderStatusRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
 type UpdateOrderStatusResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Base          *common.BaseResponse   `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
@@ -1053,7 +1206,7 @@ type UpdateOrderStatusResponse struct {
 
 func (x *UpdateOrderStatusResponse) Reset() {
 	*x = UpdateOrderStatusResponse{}
-	mi := &file_order_order_proto_msgTypes[15]
+	mi := &file_order_order_proto_msgTypes[17]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1065,7 +1218,7 @@ func (x *UpdateOrderStatusResponse) String() string {
 func (*UpdateOrderStatusResponse) ProtoMessage() {}
 
 func (x *UpdateOrderStatusResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_order_order_proto_msgTypes[15]
+	mi := &file_order_order_proto_msgTypes[17]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1078,7 +1231,7 @@ func (x *UpdateOrderStatusResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateOrderStatusResponse.ProtoReflect.Descriptor instead.
 func (*UpdateOrderStatusResponse) Descriptor() ([]byte, []int) {
-	return file_order_order_proto_rawDescGZIP(), []int{15}
+	return file_order_order_proto_rawDescGZIP(), []int{17}
 }
 
 func (x *UpdateOrderStatusResponse) GetBase() *common.BaseResponse {
@@ -1088,72 +1241,568 @@ func (x *UpdateOrderStatusResponse) GetBase() *common.BaseResponse {
 	return nil
 }
 
-var File_order_order_proto protoreflect.FileDescriptor
+type RefundOrderRequestItem struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductId     string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Quantity      int64                  `protobuf:"varint,2,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
 
-const file_order_order_proto_rawDesc = "" +
-	"\n" +
-	"\x11order/order.proto\x12\x05order\x1a\x1bbuf/validate/validate.proto\x1a\x1acommon/base_response.proto\x1a\x17common/pagination.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"K\n" +
-	"\x1dCreateOrderRequestProductItem\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1a\n" +
-	"\bquantity\x18\x02 \x01(\x03R\bquantity\"\xf6\x01\n" +
-	"\x12CreateOrderRequest\x12'\n" +
-	"\tfull_name\x18\x01 \x01(\tB\n" +
-	"\xbaH\ar\x05\x10\x01\x18\xff\x01R\bfullName\x12$\n" +
-	"\aaddress\x18\x02 \x01(\tB\n" +
-	"\xbaH\ar\x05\x10\x01\x18\xff\x01R\aaddress\x12-\n" +
-	"\fphone_number\x18\x03 \x01(\tB\n" +
-	"\xbaH\ar\x05\x10\x01\x18\xff\x01R\vphoneNumber\x12 \n" +
-	"\x05notes\x18\x04 \x01(\tB\n" +
-	"\xbaH\ar\x05\x10\x01\x18\xff\x01R\x05notes\x12@\n" +
-	"\bproducts\x18\x05 \x03(\v2$.order.CreateOrderRequestProductItemR\bproducts\"O\n" +
-	"\x13CreateOrderResponse\x12(\n" +
-	"\x04base\x18\x01 \x01(\v2\x14.common.BaseResponseR\x04base\x12\x0e\n" +
-	"\x02id\x18\x02 \x01(\tR\x02id\"R\n" +
-	"\x15ListOrderAdminRequest\x129\n" +
-	"\n" +
-	"pagination\x18\x01 \x01(\v2\x19.common.PaginationRequestR\n" +
-	"pagination\"y\n" +
-	"!ListOrderAdminResponseItemProduct\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
-	"\x04name\x18\x02 \x01(\tR\x04name\x12\x14\n" +
-	"\x05price\x18\x03 \x01(\x01R\x05price\x12\x1a\n" +
-	"\bquantity\x18\x04 \x01(\x03R\bquantity\"\x98\x02\n" +
-	"\x1aListOrderAdminResponseItem\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\x12\x16\n" +
-	"\x06number\x18\x02 \x01(\tR\x06number\x12\x1a\n" +
-	"\bcustomer\x18\x03 \x01(\tR\bcustomer\x12\x1f\n" +
-	"\vstatus_code\x18\x04 \x01(\tR\n" +
-	"statusCode\x12\x14\n" +
-	"\x05total\x18\x05 \x01(\x01R\x05total\x129\n" +
-	"\n" +
-	"created_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12D\n" +
-	"\bproducts\x18\a \x03(\v2(.order.ListOrderAdminResponseItemProductR\bproducts\"\xb5\x01\n" +
-	"\x16ListOrderAdminResponse\x12(\n" +
-	"\x04base\x18\x01 \x01(\v2\x14.common.BaseResponseR\x04base\x12:\n" +
-	"\n" +
-	"pagination\x18\x02 \x01(\v2\x1a.common.PaginationResponseR\n" +
-	"pagination\x125\n" +
-	"\x04data\x18\x03 \x03(\v2!.order.ListOrderAdminResponseItemR\x04data\"M\n" +
-	"\x10ListOrderRequest\x129\n" +
-	"\n" +
-	"pagination\x18\x01 \x01(\v2\x19.common.PaginationRequestR\n" +
-	"pagination\"t\n" +
-	"\x1cListOrderResponseItemProduct\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
-	"\x04name\x18\x02 \x01(\tR\x04name\x12\x14\n" +
-	"\x05price\x18\x03 \x01(\x01R\x05price\x12\x1a\n" +
-	"\bquantity\x18\x04 \x01(\x03R\bquantity\"\xbc\x02\n" +
-	"\x15ListOrderResponseItem\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\x12\x16\n" +
-	"\x06number\x18\x02 \x01(\tR\x06number\x12\x1a\n" +
-	"\bcustomer\x18\x03 \x01(\tR\bcustomer\x12\x1f\n" +
-	"\vstatus_code\x18\x04 \x01(\tR\n" +
+func (x *RefundOrderRequestItem) Reset() {
+	*x = RefundOrderRequestItem{}
+	mi := &file_order_order_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RefundOrderRequestItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RefundOrderRequestItem) ProtoMessage() {}
+
+func (x *RefundOrderRequestItem) ProtoReflect() protoreflect.Message {
+	mi := &file_order_order_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RefundOrderRequestItem.ProtoReflect.Descriptor instead.
+func (*RefundOrderRequestItem) Descriptor() ([]byte, []int) {
+	return file_order_order_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *RefundOrderRequestItem) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *RefundOrderRequestItem) GetQuantity() int64 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+// items empty refunds everything that is not refunded yet
+type RefundOrderRequest struct {
+	state         protoimpl.MessageState    `protogen:"open.v1"`
+	OrderId       string                    `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	Items         []*RefundOrderRequestItem `protobuf:"bytes,2,rep,name=items,proto3" json:"items,omitempty"`
+	Reason        string                    `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	Restock       bool                      `protobuf:"varint,4,opt,name=restock,proto3" json:"restock,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RefundOrderRequest) Reset() {
+	*x = RefundOrderRequest{}
+	mi := &file_order_order_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RefundOrderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RefundOrderRequest) ProtoMessage() {}
+
+func (x *RefundOrderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_order_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RefundOrderRequest.ProtoReflect.Descriptor instead.
+func (*RefundOrderRequest) Descriptor() ([]byte, []int) {
+	return file_order_order_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *RefundOrderRequest) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *RefundOrderRequest) GetItems() []*RefundOrderRequestItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *RefundOrderRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *RefundOrderRequest) GetRestock() bool {
+	if x != nil {
+		return x.Restock
+	}
+	return false
+}
+
+type RefundOrderResponse struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Base            *common.BaseResponse   `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
+	RefundId        string                 `protobuf:"bytes,2,opt,name=refund_id,json=refundId,proto3" json:"refund_id,omitempty"`
+	RefundedAmount  float64                `protobuf:"fixed64,3,opt,name=refunded_amount,json=refundedAmount,proto3" json:"refunded_amount,omitempty"`
+	OrderStatusCode string                 `protobuf:"bytes,4,opt,name=order_status_code,json=orderStatusCode,proto3" json:"order_status_code,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *RefundOrderResponse) Reset() {
+	*x = RefundOrderResponse{}
+	mi := &file_order_order_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RefundOrderResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RefundOrderResponse) ProtoMessage() {}
+
+func (x *RefundOrderResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_order_order_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RefundOrderResponse.ProtoReflect.Descriptor instead.
+func (*RefundOrderResponse) Descriptor() ([]byte, []int) {
+	return file_order_order_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *RefundOrderResponse) GetBase() *common.BaseResponse {
+	if x != nil {
+		return x.Base
+	}
+	return nil
+}
+
+func (x *RefundOrderResponse) GetRefundId() string {
+	if x != nil {
+		return x.RefundId
+	}
+	return ""
+}
+
+func (x *RefundOrderResponse) GetRefundedAmount() float64 {
+	if x != nil {
+		return x.RefundedAmount
+	}
+	return 0
+}
+
+func (x *RefundOrderResponse) GetOrderStatusCode() string {
+	if x != nil {
+		return x.OrderStatusCode
+	}
+	return ""
+}
+
+type GetOrderTimelineRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrderId       string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetOrderTimelineRequest) Reset() {
+	*x = GetOrderTimelineRequest{}
+	mi := &file_order_order_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetOrderTimelineRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOrderTimelineRequest) ProtoMessage() {}
+
+func (x *GetOrderTimelineRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_order_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOrderTimelineRequest.ProtoReflect.Descriptor instead.
+func (*GetOrderTimelineRequest) Descriptor() ([]byte, []int) {
+	return file_order_order_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *GetOrderTimelineRequest) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+type GetOrderTimelineResponseItem struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	FromStatusCode string                 `protobuf:"bytes,1,opt,name=from_status_code,json=fromStatusCode,proto3" json:"from_status_code,omitempty"`
+	ToStatusCode   string                 `protobuf:"bytes,2,opt,name=to_status_code,json=toStatusCode,proto3" json:"to_status_code,omitempty"`
+	Actor          string                 `protobuf:"bytes,3,opt,name=actor,proto3" json:"actor,omitempty"`
+	ActorRole      string                 `protobuf:"bytes,4,opt,name=actor_role,json=actorRole,proto3" json:"actor_role,omitempty"`
+	Reason         string                 `protobuf:"bytes,5,opt,name=reason,proto3" json:"reason,omitempty"`
+	CreatedAt      *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *GetOrderTimelineResponseItem) Reset() {
+	*x = GetOrderTimelineResponseItem{}
+	mi := &file_order_order_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetOrderTimelineResponseItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOrderTimelineResponseItem) ProtoMessage() {}
+
+func (x *GetOrderTimelineResponseItem) ProtoReflect() protoreflect.Message {
+	mi := &file_order_order_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOrderTimelineResponseItem.ProtoReflect.Descriptor instead.
+func (*GetOrderTimelineResponseItem) Descriptor() ([]byte, []int) {
+	return file_order_order_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *GetOrderTimelineResponseItem) GetFromStatusCode() string {
+	if x != nil {
+		return x.FromStatusCode
+	}
+	return ""
+}
+
+func (x *GetOrderTimelineResponseItem) GetToStatusCode() string {
+	if x != nil {
+		return x.ToStatusCode
+	}
+	return ""
+}
+
+func (x *GetOrderTimelineResponseItem) GetActor() string {
+	if x != nil {
+		return x.Actor
+	}
+	return ""
+}
+
+func (x *GetOrderTimelineResponseItem) GetActorRole() string {
+	if x != nil {
+		return x.ActorRole
+	}
+	return ""
+}
+
+func (x *GetOrderTimelineResponseItem) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *GetOrderTimelineResponseItem) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type GetOrderTimelineResponse struct {
+	state         protoimpl.MessageState          `protogen:"open.v1"`
+	Base          *common.BaseResponse            `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
+	Items         []*GetOrderTimelineResponseItem `protobuf:"bytes,2,rep,name=items,proto3" json:"items,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetOrderTimelineResponse) Reset() {
+	*x = GetOrderTimelineResponse{}
+	mi := &file_order_order_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetOrderTimelineResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOrderTimelineResponse) ProtoMessage() {}
+
+func (x *GetOrderTimelineResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_order_order_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOrderTimelineResponse.ProtoReflect.Descriptor instead.
+func (*GetOrderTimelineResponse) Descriptor() ([]byte, []int) {
+	return file_order_order_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *GetOrderTimelineResponse) GetBase() *common.BaseResponse {
+	if x != nil {
+		return x.Base
+	}
+	return nil
+}
+
+func (x *GetOrderTimelineResponse) GetItems() []*GetOrderTimelineResponseItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+type WatchOrderRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrderId       string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchOrderRequest) Reset() {
+	*x = WatchOrderRequest{}
+	mi := &file_order_order_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchOrderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchOrderRequest) ProtoMessage() {}
+
+func (x *WatchOrderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_order_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchOrderRequest.ProtoReflect.Descriptor instead.
+func (*WatchOrderRequest) Descriptor() ([]byte, []int) {
+	return file_order_order_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *WatchOrderRequest) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+// the first message carries the current status, the stream ends after a final status
+type WatchOrderResponse struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Base            *common.BaseResponse   `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
+	OrderId         string                 `protobuf:"bytes,2,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	OrderStatusCode string                 `protobuf:"bytes,3,opt,name=order_status_code,json=orderStatusCode,proto3" json:"order_status_code,omitempty"`
+	UpdatedAt       *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *WatchOrderResponse) Reset() {
+	*x = WatchOrderResponse{}
+	mi := &file_order_order_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchOrderResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchOrderResponse) ProtoMessage() {}
+
+func (x *WatchOrderResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_order_order_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchOrderResponse.ProtoReflect.Descriptor instead.
+func (*WatchOrderResponse) Descriptor() ([]byte, []int) {
+	return file_order_order_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *WatchOrderResponse) GetBase() *common.BaseResponse {
+	if x != nil {
+		return x.Base
+	}
+	return nil
+}
+
+func (x *WatchOrderResponse) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *WatchOrderResponse) GetOrderStatusCode() string {
+	if x != nil {
+		return x.OrderStatusCode
+	}
+	return ""
+}
+
+func (x *WatchOrderResponse) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+var File_order_order_proto protoreflect.FileDescriptor
+
+const file_order_order_proto_rawDesc = "" +
+	"\n" +
+	"\x11order/order.proto\x12\x05order\x1a\x1bbuf/validate/validate.proto\x1a\x1acommon/base_response.proto\x1a\x17common/pagination.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"`\n" +
+	"\x1dCreateOrderRequestProductItem\x12\x1a\n" +
+	"\x02id\x18\x01 \x01(\tB\n" +
+	"\xbaH\ar\x05\x10\x01\x18\xff\x01R\x02id\x12#\n" +
+	"\bquantity\x18\x02 \x01(\x03B\a\xbaH\x04\"\x02 \x00R\bquantity\"\x80\x02\n" +
+	"\x12CreateOrderRequest\x12'\n" +
+	"\tfull_name\x18\x01 \x01(\tB\n" +
+	"\xbaH\ar\x05\x10\x01\x18\xff\x01R\bfullName\x12$\n" +
+	"\aaddress\x18\x02 \x01(\tB\n" +
+	"\xbaH\ar\x05\x10\x01\x18\xff\x01R\aaddress\x12-\n" +
+	"\fphone_number\x18\x03 \x01(\tB\n" +
+	"\xbaH\ar\x05\x10\x01\x18\xff\x01R\vphoneNumber\x12 \n" +
+	"\x05notes\x18\x04 \x01(\tB\n" +
+	"\xbaH\ar\x05\x10\x01\x18\xff\x01R\x05notes\x12J\n" +
+	"\bproducts\x18\x05 \x03(\v2$.order.CreateOrderRequestProductItemB\b\xbaH\x05\x92\x01\x02\b\x01R\bproducts\"O\n" +
+	"\x13CreateOrderResponse\x12(\n" +
+	"\x04base\x18\x01 \x01(\v2\x14.common.BaseResponseR\x04base\x12\x0e\n" +
+	"\x02id\x18\x02 \x01(\tR\x02id\"\xd0\x01\n" +
+	"\x13CheckoutCartRequest\x12'\n" +
+	"\tfull_name\x18\x01 \x01(\tB\n" +
+	"\xbaH\ar\x05\x10\x01\x18\xff\x01R\bfullName\x12$\n" +
+	"\aaddress\x18\x02 \x01(\tB\n" +
+	"\xbaH\ar\x05\x10\x01\x18\xff\x01R\aaddress\x12-\n" +
+	"\fphone_number\x18\x03 \x01(\tB\n" +
+	"\xbaH\ar\x05\x10\x01\x18\xff\x01R\vphoneNumber\x12 \n" +
+	"\x05notes\x18\x04 \x01(\tB\n" +
+	"\xbaH\ar\x05\x10\x01\x18\xff\x01R\x05notes\x12\x19\n" +
+	"\bcart_ids\x18\x05 \x03(\tR\acartIds\"P\n" +
+	"\x14CheckoutCartResponse\x12(\n" +
+	"\x04base\x18\x01 \x01(\v2\x14.common.BaseResponseR\x04base\x12\x0e\n" +
+	"\x02id\x18\x02 \x01(\tR\x02id\"R\n" +
+	"\x15ListOrderAdminRequest\x129\n" +
+	"\n" +
+	"pagination\x18\x01 \x01(\v2\x19.common.PaginationRequestR\n" +
+	"pagination\"y\n" +
+	"!ListOrderAdminResponseItemProduct\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x14\n" +
+	"\x05price\x18\x03 \x01(\x01R\x05price\x12\x1a\n" +
+	"\bquantity\x18\x04 \x01(\x03R\bquantity\"\x98\x02\n" +
+	"\x1aListOrderAdminResponseItem\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x16\n" +
+	"\x06number\x18\x02 \x01(\tR\x06number\x12\x1a\n" +
+	"\bcustomer\x18\x03 \x01(\tR\bcustomer\x12\x1f\n" +
+	"\vstatus_code\x18\x04 \x01(\tR\n" +
+	"statusCode\x12\x14\n" +
+	"\x05total\x18\x05 \x01(\x01R\x05total\x129\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12D\n" +
+	"\bproducts\x18\a \x03(\v2(.order.ListOrderAdminResponseItemProductR\bproducts\"\xb5\x01\n" +
+	"\x16ListOrderAdminResponse\x12(\n" +
+	"\x04base\x18\x01 \x01(\v2\x14.common.BaseResponseR\x04base\x12:\n" +
+	"\n" +
+	"pagination\x18\x02 \x01(\v2\x1a.common.PaginationResponseR\n" +
+	"pagination\x125\n" +
+	"\x04data\x18\x03 \x03(\v2!.order.ListOrderAdminResponseItemR\x04data\"M\n" +
+	"\x10ListOrderRequest\x129\n" +
+	"\n" +
+	"pagination\x18\x01 \x01(\v2\x19.common.PaginationRequestR\n" +
+	"pagination\"t\n" +
+	"\x1cListOrderResponseItemProduct\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x14\n" +
+	"\x05price\x18\x03 \x01(\x01R\x05price\x12\x1a\n" +
+	"\bquantity\x18\x04 \x01(\x03R\bquantity\"\xbe\x02\n" +
+	"\x15ListOrderResponseItem\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x16\n" +
+	"\x06number\x18\x02 \x01(\tR\x06number\x12\x1a\n" +
+	"\bcustomer\x18\x03 \x01(\tR\bcustomer\x12\x1f\n" +
+	"\vstatus_code\x18\x04 \x01(\tR\n" +
 	"statusCode\x12\x14\n" +
 	"\x05total\x18\x05 \x01(\x01R\x05total\x129\n" +
 	"\n" +
 	"created_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12?\n" +
-	"\bproducts\x18\a \x03(\v2#.order.ListOrderResponseItemProductR\bproducts\x12,\n" +
-	"\x12xendit_invoice_url\x18\b \x01(\tR\x10xenditInvoiceUrl\"\xab\x01\n" +
+	"\bproducts\x18\a \x03(\v2#.order.ListOrderResponseItemProductR\bproducts\x12.\n" +
+	"\x13payment_invoice_url\x18\b \x01(\tR\x11paymentInvoiceUrl\"\xab\x01\n" +
 	"\x11ListOrderResponse\x12(\n" +
 	"\x04base\x18\x01 \x01(\v2\x14.common.BaseResponseR\x04base\x12:\n" +
 	"\n" +
@@ -1162,12 +1811,13 @@ const file_order_order_proto_rawDesc = "" +
 	"\x04data\x18\x03 \x03(\v2\x1c.order.ListOrderResponseItemR\x04data\"0\n" +
 	"\x12DetailOrderRequest\x12\x1a\n" +
 	"\x02id\x18\x01 \x01(\tB\n" +
-	"\xbaH\ar\x05\x10\x01\x18\xff\x01R\x02id\"o\n" +
+	"\xbaH\ar\x05\x10\x01\x18\xff\x01R\x02id\"\x9c\x01\n" +
 	"\x17DetailOrderResponseItem\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12\x14\n" +
 	"\x05price\x18\x03 \x01(\x01R\x05price\x12\x1a\n" +
-	"\bquantity\x18\x04 \x01(\x03R\bquantity\"\xfc\x03\n" +
+	"\bquantity\x18\x04 \x01(\x03R\bquantity\x12+\n" +
+	"\x11refunded_quantity\x18\x05 \x01(\x03R\x10refundedQuantity\"\xa5\x04\n" +
 	"\x13DetailOrderResponse\x12(\n" +
 	"\x04base\x18\x01 \x01(\v2\x14.common.BaseResponseR\x04base\x12\x0e\n" +
 	"\x02id\x18\x02 \x01(\tR\x02id\x12\x16\n" +
@@ -1178,26 +1828,74 @@ const file_order_order_proto_rawDesc = "" +
 	"\x05notes\x18\a \x01(\tR\x05notes\x12*\n" +
 	"\x11order_status_code\x18\b \x01(\tR\x0forderStatusCode\x129\n" +
 	"\n" +
-	"created_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12,\n" +
-	"\x12xendit_invoice_url\x18\n" +
-	" \x01(\tR\x10xenditInvoiceUrl\x124\n" +
+	"created_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12.\n" +
+	"\x13payment_invoice_url\x18\n" +
+	" \x01(\tR\x11paymentInvoiceUrl\x124\n" +
 	"\x05items\x18\v \x03(\v2\x1e.order.DetailOrderResponseItemR\x05items\x12\x14\n" +
 	"\x05total\x18\f \x01(\x01R\x05total\x129\n" +
 	"\n" +
-	"expired_at\x18\r \x01(\v2\x1a.google.protobuf.TimestampR\texpiredAt\"u\n" +
+	"expired_at\x18\r \x01(\v2\x1a.google.protobuf.TimestampR\texpiredAt\x12%\n" +
+	"\x0erefunded_total\x18\x0e \x01(\x01R\rrefundedTotal\"\x97\x01\n" +
 	"\x18UpdateOrderStatusRequest\x12%\n" +
 	"\border_id\x18\x01 \x01(\tB\n" +
 	"\xbaH\ar\x05\x10\x01\x18\xff\x01R\aorderId\x122\n" +
 	"\x0fnew_status_code\x18\x02 \x01(\tB\n" +
-	"\xbaH\ar\x05\x10\x01\x18\xff\x01R\rnewStatusCode\"E\n" +
+	"\xbaH\ar\x05\x10\x01\x18\xff\x01R\rnewStatusCode\x12 \n" +
+	"\x06reason\x18\x03 \x01(\tB\b\xbaH\x05r\x03\x18\xff\x01R\x06reason\"E\n" +
 	"\x19UpdateOrderStatusResponse\x12(\n" +
-	"\x04base\x18\x01 \x01(\v2\x14.common.BaseResponseR\x04base2\x81\x03\n" +
+	"\x04base\x18\x01 \x01(\v2\x14.common.BaseResponseR\x04base\"h\n" +
+	"\x16RefundOrderRequestItem\x12)\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tB\n" +
+	"\xbaH\ar\x05\x10\x01\x18\xff\x01R\tproductId\x12#\n" +
+	"\bquantity\x18\x02 \x01(\x03B\a\xbaH\x04\"\x02 \x00R\bquantity\"\xae\x01\n" +
+	"\x12RefundOrderRequest\x12%\n" +
+	"\border_id\x18\x01 \x01(\tB\n" +
+	"\xbaH\ar\x05\x10\x01\x18\xff\x01R\aorderId\x123\n" +
+	"\x05items\x18\x02 \x03(\v2\x1d.order.RefundOrderRequestItemR\x05items\x12\"\n" +
+	"\x06reason\x18\x03 \x01(\tB\n" +
+	"\xbaH\ar\x05\x10\x01\x18\xff\x01R\x06reason\x12\x18\n" +
+	"\arestock\x18\x04 \x01(\bR\arestock\"\xb1\x01\n" +
+	"\x13RefundOrderResponse\x12(\n" +
+	"\x04base\x18\x01 \x01(\v2\x14.common.BaseResponseR\x04base\x12\x1b\n" +
+	"\trefund_id\x18\x02 \x01(\tR\brefundId\x12'\n" +
+	"\x0frefunded_amount\x18\x03 \x01(\x01R\x0erefundedAmount\x12*\n" +
+	"\x11order_status_code\x18\x04 \x01(\tR\x0forderStatusCode\"@\n" +
+	"\x17GetOrderTimelineRequest\x12%\n" +
+	"\border_id\x18\x01 \x01(\tB\n" +
+	"\xbaH\ar\x05\x10\x01\x18\xff\x01R\aorderId\"\xf6\x01\n" +
+	"\x1cGetOrderTimelineResponseItem\x12(\n" +
+	"\x10from_status_code\x18\x01 \x01(\tR\x0efromStatusCode\x12$\n" +
+	"\x0eto_status_code\x18\x02 \x01(\tR\ftoStatusCode\x12\x14\n" +
+	"\x05actor\x18\x03 \x01(\tR\x05actor\x12\x1d\n" +
+	"\n" +
+	"actor_role\x18\x04 \x01(\tR\tactorRole\x12\x16\n" +
+	"\x06reason\x18\x05 \x01(\tR\x06reason\x129\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"\x7f\n" +
+	"\x18GetOrderTimelineResponse\x12(\n" +
+	"\x04base\x18\x01 \x01(\v2\x14.common.BaseResponseR\x04base\x129\n" +
+	"\x05items\x18\x02 \x03(\v2#.order.GetOrderTimelineResponseItemR\x05items\":\n" +
+	"\x11WatchOrderRequest\x12%\n" +
+	"\border_id\x18\x01 \x01(\tB\n" +
+	"\xbaH\ar\x05\x10\x01\x18\xff\x01R\aorderId\"\xc0\x01\n" +
+	"\x12WatchOrderResponse\x12(\n" +
+	"\x04base\x18\x01 \x01(\v2\x14.common.BaseResponseR\x04base\x12\x19\n" +
+	"\border_id\x18\x02 \x01(\tR\aorderId\x12*\n" +
+	"\x11order_status_code\x18\x03 \x01(\tR\x0forderStatusCode\x129\n" +
+	"\n" +
+	"updated_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt2\xaa\x05\n" +
 	"\fOrderService\x12D\n" +
-	"\vCreateOrder\x12\x19.order.CreateOrderRequest\x1a\x1a.order.CreateOrderResponse\x12M\n" +
+	"\vCreateOrder\x12\x19.order.CreateOrderRequest\x1a\x1a.order.CreateOrderResponse\x12G\n" +
+	"\fCheckoutCart\x12\x1a.order.CheckoutCartRequest\x1a\x1b.order.CheckoutCartResponse\x12M\n" +
 	"\x0eListOrderAdmin\x12\x1c.order.ListOrderAdminRequest\x1a\x1d.order.ListOrderAdminResponse\x12>\n" +
 	"\tListOrder\x12\x17.order.ListOrderRequest\x1a\x18.order.ListOrderResponse\x12D\n" +
 	"\vDetailOrder\x12\x19.order.DetailOrderRequest\x1a\x1a.order.DetailOrderResponse\x12V\n" +
-	"\x11UpdateOrderStatus\x12\x1f.order.UpdateOrderStatusRequest\x1a .order.UpdateOrderStatusResponseB,Z*github.com/arthurhzna/Golang_gRPC/pb/orderb\x06proto3"
+	"\x11UpdateOrderStatus\x12\x1f.order.UpdateOrderStatusRequest\x1a .order.UpdateOrderStatusResponse\x12D\n" +
+	"\vRefundOrder\x12\x19.order.RefundOrderRequest\x1a\x1a.order.RefundOrderResponse\x12S\n" +
+	"\x10GetOrderTimeline\x12\x1e.order.GetOrderTimelineRequest\x1a\x1f.order.GetOrderTimelineResponse\x12C\n" +
+	"\n" +
+	"WatchOrder\x12\x18.order.WatchOrderRequest\x1a\x19.order.WatchOrderResponse0\x01B,Z*github.com/arthurhzna/Golang_gRPC/pb/orderb\x06proto3"
 
 var (
 	file_order_order_proto_rawDescOnce sync.Once
@@ -1211,64 +1909,90 @@ func file_order_order_proto_rawDescGZIP() []byte {
 	return file_order_order_proto_rawDescData
 }
 
-var file_order_order_proto_msgTypes = make([]protoimpl.MessageInfo, 16)
+var file_order_order_proto_msgTypes = make([]protoimpl.MessageInfo, 26)
 var file_order_order_proto_goTypes = []any{
 	(*CreateOrderRequestProductItem)(nil),     // 0: order.CreateOrderRequestProductItem
 	(*CreateOrderRequest)(nil),                // 1: order.CreateOrderRequest
 	(*CreateOrderResponse)(nil),               // 2: order.CreateOrderResponse
-	(*ListOrderAdminRequest)(nil),             // 3: order.ListOrderAdminRequest
-	(*ListOrderAdminResponseItemProduct)(nil), // 4: order.ListOrderAdminResponseItemProduct
-	(*ListOrderAdminResponseItem)(nil),        // 5: order.ListOrderAdminResponseItem
-	(*ListOrderAdminResponse)(nil),            // 6: order.ListOrderAdminResponse
-	(*ListOrderRequest)(nil),                  // 7: order.ListOrderRequest
-	(*ListOrderResponseItemProduct)(nil),      // 8: order.ListOrderResponseItemProduct
-	(*ListOrderResponseItem)(nil),             // 9: order.ListOrderResponseItem
-	(*ListOrderResponse)(nil),                 // 10: order.ListOrderResponse
-	(*DetailOrderRequest)(nil),                // 11: order.DetailOrderRequest
-	(*DetailOrderResponseItem)(nil),           // 12: order.DetailOrderResponseItem
-	(*DetailOrderResponse)(nil),               // 13: order.DetailOrderResponse
-	(*UpdateOrderStatusRequest)(nil),          // 14: order.UpdateOrderStatusRequest
-	(*UpdateOrderStatusResponse)(nil),         // 15: order.UpdateOrderStatusResponse
-	(*common.BaseResponse)(nil),               // 16: common.BaseResponse
-	(*common.PaginationRequest)(nil),          // 17: common.PaginationRequest
-	(*timestamppb.Timestamp)(nil),             // 18: google.protobuf.Timestamp
-	(*common.PaginationResponse)(nil),         // 19: common.PaginationResponse
+	(*CheckoutCartRequest)(nil),               // 3: order.CheckoutCartRequest
+	(*CheckoutCartResponse)(nil),              // 4: order.CheckoutCartResponse
+	(*ListOrderAdminRequest)(nil),             // 5: order.ListOrderAdminRequest
+	(*ListOrderAdminResponseItemProduct)(nil), // 6: order.ListOrderAdminResponseItemProduct
+	(*ListOrderAdminResponseItem)(nil),        // 7: order.ListOrderAdminResponseItem
+	(*ListOrderAdminResponse)(nil),            // 8: order.ListOrderAdminResponse
+	(*ListOrderRequest)(nil),                  // 9: order.ListOrderRequest
+	(*ListOrderResponseItemProduct)(nil),      // 10: order.ListOrderResponseItemProduct
+	(*ListOrderResponseItem)(nil),             // 11: order.ListOrderResponseItem
+	(*ListOrderResponse)(nil),                 // 12: order.ListOrderResponse
+	(*DetailOrderRequest)(nil),                // 13: order.DetailOrderRequest
+	(*DetailOrderResponseItem)(nil),           // 14: order.DetailOrderResponseItem
+	(*DetailOrderResponse)(nil),               // 15: order.DetailOrderResponse
+	(*UpdateOrderStatusRequest)(nil),          // 16: order.UpdateOrderStatusRequest
+	(*UpdateOrderStatusResponse)(nil),         // 17: order.UpdateOrderStatusResponse
+	(*RefundOrderRequestItem)(nil),            // 18: order.RefundOrderRequestItem
+	(*RefundOrderRequest)(nil),                // 19: order.RefundOrderRequest
+	(*RefundOrderResponse)(nil),               // 20: order.RefundOrderResponse
+	(*GetOrderTimelineRequest)(nil),           // 21: order.GetOrderTimelineRequest
+	(*GetOrderTimelineResponseItem)(nil),      // 22: order.GetOrderTimelineResponseItem
+	(*GetOrderTimelineResponse)(nil),          // 23: order.GetOrderTimelineResponse
+	(*WatchOrderRequest)(nil),                 // 24: order.WatchOrderRequest
+	(*WatchOrderResponse)(nil),                // 25: order.WatchOrderResponse
+	(*common.BaseResponse)(nil),               // 26: common.BaseResponse
+	(*common.PaginationRequest)(nil),          // 27: common.PaginationRequest
+	(*timestamppb.Timestamp)(nil),             // 28: google.protobuf.Timestamp
+	(*common.PaginationResponse)(nil),         // 29: common.PaginationResponse
 }
 var file_order_order_proto_depIdxs = []int32{
 	0,  // 0: order.CreateOrderRequest.products:type_name -> order.CreateOrderRequestProductItem
-	16, // 1: order.CreateOrderResponse.base:type_name -> common.BaseResponse
-	17, // 2: order.ListOrderAdminRequest.pagination:type_name -> common.PaginationRequest
-	18, // 3: order.ListOrderAdminResponseItem.created_at:type_name -> google.protobuf.Timestamp
-	4,  // 4: order.ListOrderAdminResponseItem.products:type_name -> order.ListOrderAdminResponseItemProduct
-	16, // 5: order.ListOrderAdminResponse.base:type_name -> common.BaseResponse
-	19, // 6: order.ListOrderAdminResponse.pagination:type_name -> common.PaginationResponse
-	5,  // 7: order.ListOrderAdminResponse.data:type_name -> order.ListOrderAdminResponseItem
-	17, // 8: order.ListOrderRequest.pagination:type_name -> common.PaginationRequest
-	18, // 9: order.ListOrderResponseItem.created_at:type_name -> google.protobuf.Timestamp
-	8,  // 10: order.ListOrderResponseItem.products:type_name -> order.ListOrderResponseItemProduct
-	16, // 11: order.ListOrderResponse.base:type_name -> common.BaseResponse
-	19, // 12: order.ListOrderResponse.pagination:type_name -> common.PaginationResponse
-	9,  // 13: order.ListOrderResponse.data:type_name -> order.ListOrderResponseItem
-	16, // 14: order.DetailOrderResponse.base:type_name -> common.BaseResponse
-	18, // 15: order.DetailOrderResponse.created_at:type_name -> google.protobuf.Timestamp
-	12, // 16: order.DetailOrderResponse.items:type_name -> order.DetailOrderResponseItem
-	18, // 17: order.DetailOrderResponse.expired_at:type_name -> google.protobuf.Timestamp
-	16, // 18: order.UpdateOrderStatusResponse.base:type_name -> common.BaseResponse
-	1,  // 19: order.OrderService.CreateOrder:input_type -> order.CreateOrderRequest
-	3,  // 20: order.OrderService.ListOrderAdmin:input_type -> order.ListOrderAdminRequest
-	7,  // 21: order.OrderService.ListOrder:input_type -> order.ListOrderRequest
-	11, // 22: order.OrderService.DetailOrder:input_type -> order.DetailOrderRequest
-	14, // 23: order.OrderService.UpdateOrderStatus:input_type -> order.UpdateOrderStatusRequest
-	2,  // 24: order.OrderService.CreateOrder:output_type -> order.CreateOrderResponse
-	6,  // 25: order.OrderService.ListOrderAdmin:output_type -> order.ListOrderAdminResponse
-	10, // 26: order.OrderService.ListOrder:output_type -> order.ListOrderResponse
-	13, // 27: order.OrderService.DetailOrder:output_type -> order.DetailOrderResponse
-	15, // 28: order.OrderService.UpdateOrderStatus:output_type -> order.UpdateOrderStatusResponse
-	24, // [24:29] is the sub-list for method output_type
-	19, // [19:24] is the sub-list for method input_type
-	19, // [19:19] is the sub-list for extension type_name
-	19, // [19:19] is the sub-list for extension extendee
-	0,  // [0:19] is the sub-list for field type_name
+	26, // 1: order.CreateOrderResponse.base:type_name -> common.BaseResponse
+	26, // 2: order.CheckoutCartResponse.base:type_name -> common.BaseResponse
+	27, // 3: order.ListOrderAdminRequest.pagination:type_name -> common.PaginationRequest
+	28, // 4: order.ListOrderAdminResponseItem.created_at:type_name -> google.protobuf.Timestamp
+	6,  // 5: order.ListOrderAdminResponseItem.products:type_name -> order.ListOrderAdminResponseItemProduct
+	26, // 6: order.ListOrderAdminResponse.base:type_name -> common.BaseResponse
+	29, // 7: order.ListOrderAdminResponse.pagination:type_name -> common.PaginationResponse
+	7,  // 8: order.ListOrderAdminResponse.data:type_name -> order.ListOrderAdminResponseItem
+	27, // 9: order.ListOrderRequest.pagination:type_name -> common.PaginationRequest
+	28, // 10: order.ListOrderResponseItem.created_at:type_name -> google.protobuf.Timestamp
+	10, // 11: order.ListOrderResponseItem.products:type_name -> order.ListOrderResponseItemProduct
+	26, // 12: order.ListOrderResponse.base:type_name -> common.BaseResponse
+	29, // 13: order.ListOrderResponse.pagination:type_name -> common.PaginationResponse
+	11, // 14: order.ListOrderResponse.data:type_name -> order.ListOrderResponseItem
+	26, // 15: order.DetailOrderResponse.base:type_name -> common.BaseResponse
+	28, // 16: order.DetailOrderResponse.created_at:type_name -> google.protobuf.Timestamp
+	14, // 17: order.DetailOrderResponse.items:type_name -> order.DetailOrderResponseItem
+	28, // 18: order.DetailOrderResponse.expired_at:type_name -> google.protobuf.Timestamp
+	26, // 19: order.UpdateOrderStatusResponse.base:type_name -> common.BaseResponse
+	18, // 20: order.RefundOrderRequest.items:type_name -> order.RefundOrderRequestItem
+	26, // 21: order.RefundOrderResponse.base:type_name -> common.BaseResponse
+	28, // 22: order.GetOrderTimelineResponseItem.created_at:type_name -> google.protobuf.Timestamp
+	26, // 23: order.GetOrderTimelineResponse.base:type_name -> common.BaseResponse
+	22, // 24: order.GetOrderTimelineResponse.items:type_name -> order.GetOrderTimelineResponseItem
+	26, // 25: order.WatchOrderResponse.base:type_name -> common.BaseResponse
+	28, // 26: order.WatchOrderResponse.updated_at:type_name -> google.protobuf.Timestamp
+	1,  // 27: order.OrderService.CreateOrder:input_type -> order.CreateOrderRequest
+	3,  // 28: order.OrderService.CheckoutCart:input_type -> order.CheckoutCartRequest
+	5,  // 29: order.OrderService.ListOrderAdmin:input_type -> order.ListOrderAdminRequest
+	9,  // 30: order.OrderService.ListOrder:input_type -> order.ListOrderRequest
+	13, // 31: order.OrderService.DetailOrder:input_type -> order.DetailOrderRequest
+	16, // 32: order.OrderService.UpdateOrderStatus:input_type -> order.UpdateOrderStatusRequest
+	19, // 33: order.OrderService.RefundOrder:input_type -> order.RefundOrderRequest
+	21, // 34: order.OrderService.GetOrderTimeline:input_type -> order.GetOrderTimelineRequest
+	24, // 35: order.OrderService.WatchOrder:input_type -> order.WatchOrderRequest
+	2,  // 36: order.OrderService.CreateOrder:output_type -> order.CreateOrderResponse
+	4,  // 37: order.OrderService.CheckoutCart:output_type -> order.CheckoutCartResponse
+	8,  // 38: order.OrderService.ListOrderAdmin:output_type -> order.ListOrderAdminResponse
+	12, // 39: order.OrderService.ListOrder:output_type -> order.ListOrderResponse
+	15, // 40: order.OrderService.DetailOrder:output_type -> order.DetailOrderResponse
+	17, // 41: order.OrderService.UpdateOrderStatus:output_type -> order.UpdateOrderStatusResponse
+	20, // 42: order.OrderService.RefundOrder:output_type -> order.RefundOrderResponse
+	23, // 43: order.OrderService.GetOrderTimeline:output_type -> order.GetOrderTimelineResponse
+	25, // 44: order.OrderService.WatchOrder:output_type -> order.WatchOrderResponse
+	36, // [36:45] is the sub-list for method output_type
+	27, // [27:36] is the sub-list for method input_type
+	27, // [27:27] is the sub-list for extension type_name
+	27, // [27:27] is the sub-list for extension extendee
+	0,  // [0:27] is the sub-list for field type_name
 }
 
 func init() { file_order_order_proto_init() }
@@ -1282,7 +2006,7 @@ func file_order_order_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_order_order_proto_rawDesc), len(file_order_order_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   16,
+			NumMessages:   26,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -1,12 +1,12 @@
-package database
-
-import (
-	"context"
-	"database/sql"
-)
-
-type DatabaseQuery interface {
-	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
-	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
-	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
-}
+package database
+
+import (
+	"context"
+	"database/sql"
+)
+
+type DatabaseQuery interface {
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
@@ -1,17 +1,42 @@
-CREATE TABLE public.newsletter ( id bigint GENERATED ALWAYS AS IDENTITY NOT NULL, full_name character varying NOT NULL, email character varying NOT NULL, created_at timestamp with time zone NOT NULL DEFAULT now(), created_by character varying NOT NULL, updated_at timestamp with time zone, updated_by character varying, deleted_at timestamp with time zone, deleted_by character varying, is_deleted boolean NOT NULL DEFAULT false, CONSTRAINT newsletter_pkey PRIMARY KEY (id) );
-
-CREATE TABLE public.numbering ( module character varying NOT NULL, number bigint NOT NULL, CONSTRAINT numbering_pkey PRIMARY KEY (module) );
-
-CREATE TABLE public.user_role ( id uuid NOT NULL DEFAULT gen_random_uuid(), name character varying NOT NULL DEFAULT ''::character varying, code character varying NOT NULL DEFAULT ''::character varying UNIQUE, created_at timestamp with time zone NOT NULL, created_by character varying DEFAULT ''::character varying, update_at timestamp with time zone, updated_by character varying, deleted_at timestamp with time zone, deleted_by character varying, is_deleted boolean NOT NULL DEFAULT false, CONSTRAINT user_role_pkey PRIMARY KEY (id) );
-
-CREATE TABLE public."user" ( id uuid NOT NULL DEFAULT gen_random_uuid(), full_name character varying NOT NULL DEFAULT ''::character varying, email character varying NOT NULL DEFAULT ''::character varying, password character varying NOT NULL DEFAULT ''::character varying, role_code character varying NOT NULL DEFAULT gen_random_uuid(), created_at timestamp with time zone NOT NULL DEFAULT now(), created_by character varying DEFAULT ''::character varying, updated_at timestamp with time zone, updated_by character varying, deleted_at timestamp with time zone, deleted_by character varying, is_deleted boolean DEFAULT false, CONSTRAINT user_pkey PRIMARY KEY (id), CONSTRAINT user_role_code_fkey FOREIGN KEY (role_code) REFERENCES public.user_role(code) );
-
-CREATE TABLE public.product ( id uuid NOT NULL DEFAULT gen_random_uuid(), name character varying NOT NULL, price numeric NOT NULL, description character varying NOT NULL DEFAULT ''::character varying, image_file_name character varying NOT NULL, created_at timestamp with time zone NOT NULL DEFAULT now(), created_by character varying NOT NULL, updated_at timestamp with time zone, updated_by character varying, deleted_at timestamp with time zone, deleted_by character varying, is_deleted boolean DEFAULT false, CONSTRAINT product_pkey PRIMARY KEY (id) );
-
-CREATE TABLE public.order_status ( id uuid NOT NULL DEFAULT gen_random_uuid(), name character varying NOT NULL, code character varying NOT NULL UNIQUE, created_at timestamp with time zone NOT NULL DEFAULT now(), created_by character varying NOT NULL, updated_at timestamp with time zone, updated_by character varying, deleted_at timestamp with time zone, deleted_by character varying, is_deleted boolean DEFAULT false, CONSTRAINT order_status_pkey PRIMARY KEY (id) );
-
-CREATE TABLE public."order" ( id uuid NOT NULL DEFAULT gen_random_uuid(), number character varying NOT NULL, user_id uuid NOT NULL DEFAULT gen_random_uuid(), order_status_code character varying NOT NULL, user_full_name character varying NOT NULL, address character varying NOT NULL, phone_number character varying NOT NULL, notes character varying, total numeric NOT NULL, expired_at timestamp with time zone NOT NULL, created_at timestamp with time zone NOT NULL DEFAULT now(), created_by character varying NOT NULL, updated_at timestamp with time zone, updated_by character varying, deleted_at timestamp with time zone, deleted_by character varying, is_deleted boolean DEFAULT false, xendit_invoice_id character varying, xendit_invoice_url character varying, xendit_paid_at timestamp with time zone, xendit_payment_method character varying, xendit_payment_channel character varying, CONSTRAINT order_pkey PRIMARY KEY (id), CONSTRAINT order_user_id_fkey FOREIGN KEY (user_id) REFERENCES public."user"(id), CONSTRAINT order_order_status_code_fkey FOREIGN KEY (order_status_code) REFERENCES public.order_status(code) );
-
-CREATE TABLE public.order_item ( id bigint GENERATED ALWAYS AS IDENTITY NOT NULL, product_id uuid NOT NULL DEFAULT gen_random_uuid(), product_name character varying NOT NULL, product_image_file_name character varying NOT NULL, product_price numeric NOT NULL, quantity bigint NOT NULL, order_id uuid, created_at timestamp with time zone NOT NULL DEFAULT now(), created_by character varying NOT NULL, updated_at timestamp with time zone, updated_by character varying, deleted_at timestamp with time zone, deleted_by character varying, is_deleted boolean DEFAULT false, CONSTRAINT order_item_pkey PRIMARY KEY (id), CONSTRAINT order_item_product_id_fkey FOREIGN KEY (product_id) REFERENCES public.product(id), CONSTRAINT order_item_order_id_fkey FOREIGN KEY (order_id) REFERENCES public."order"(id) );
-
-CREATE TABLE public.user_cart ( id bigint GENERATED ALWAYS AS IDENTITY NOT NULL, product_id uuid NOT NULL DEFAULT gen_random_uuid(), user_id uuid NOT NULL DEFAULT gen_random_uuid(), quantity bigint NOT NULL DEFAULT '0'::bigint, created_at timestamp with time zone NOT NULL DEFAULT now(), created_by character varying NOT NULL DEFAULT ''::character varying, updated_at timestamp with time zone, updated_by character varying, CONSTRAINT user_cart_pkey PRIMARY KEY (id), CONSTRAINT user_cart_product_id_fkey FOREIGN KEY (product_id) REFERENCES public.product(id), CONSTRAINT user_cart_user_id_fkey FOREIGN KEY (user_id) REFERENCES public."user"(id) );
\ No newline at end of file
+CREATE TABLE public.newsletter ( id bigint GENERATED ALWAYS AS IDENTITY NOT NULL, full_name character varying NOT NULL, email character varying NOT NULL, created_at timestamp with time zone NOT NULL DEFAULT now(), created_by character varying NOT NULL, updated_at timestamp with time zone, updated_by character varying, deleted_at timestamp with time zone, deleted_by character varying, is_deleted boolean NOT NULL DEFAULT false, CONSTRAINT newsletter_pkey PRIMARY KEY (id) );
+
+CREATE TABLE public.numbering ( module character varying NOT NULL, number bigint NOT NULL, CONSTRAINT numbering_pkey PRIMARY KEY (module) );
+
+CREATE TABLE public.user_role ( id uuid NOT NULL DEFAULT gen_random_uuid(), name character varying NOT NULL DEFAULT ''::character varying, code character varying NOT NULL DEFAULT ''::character varying UNIQUE, created_at timestamp with time zone NOT NULL, created_by character varying DEFAULT ''::character varying, update_at timestamp with time zone, updated_by character varying, deleted_at timestamp with time zone, deleted_by character varying, is_deleted boolean NOT NULL DEFAULT false, CONSTRAINT user_role_pkey PRIMARY KEY (id) );
+
+CREATE TABLE public."user" ( id uuid NOT NULL DEFAULT gen_random_uuid(), full_name character varying NOT NULL DEFAULT ''::character varying, email character varying NOT NULL DEFAULT ''::character varying, password character varying NOT NULL DEFAULT ''::character varying, role_code character varying NOT NULL DEFAULT gen_random_uuid(), created_at timestamp with time zone NOT NULL DEFAULT now(), created_by character varying DEFAULT ''::character varying, updated_at timestamp with time zone, updated_by character varying, deleted_at timestamp with time zone, deleted_by character varying, is_deleted boolean DEFAULT false, email_verified_at timestamp with time zone, phone_number character varying, address character varying, totp_secret character varying, totp_enabled_at timestamp with time zone, totp_last_used_step bigint, CONSTRAINT user_pkey PRIMARY KEY (id), CONSTRAINT user_role_code_fkey FOREIGN KEY (role_code) REFERENCES public.user_role(code) );
+
+CREATE TABLE public.product ( id uuid NOT NULL DEFAULT gen_random_uuid(), name character varying NOT NULL, price numeric NOT NULL, description character varying NOT NULL DEFAULT ''::character varying, image_file_name character varying NOT NULL, stock bigint NOT NULL DEFAULT 0, created_at timestamp with time zone NOT NULL DEFAULT now(), created_by character varying NOT NULL, updated_at timestamp with time zone, updated_by character varying, deleted_at timestamp with time zone, deleted_by character varying, is_deleted boolean DEFAULT false, CONSTRAINT product_stock_check CHECK (stock >= 0), CONSTRAINT product_pkey PRIMARY KEY (id) );
+
+CREATE TABLE public.order_status ( id uuid NOT NULL DEFAULT gen_random_uuid(), name character varying NOT NULL, code character varying NOT NULL UNIQUE, created_at timestamp with time zone NOT NULL DEFAULT now(), created_by character varying NOT NULL, updated_at timestamp with time zone, updated_by character varying, deleted_at timestamp with time zone, deleted_by character varying, is_deleted boolean DEFAULT false, CONSTRAINT order_status_pkey PRIMARY KEY (id) );
+
+CREATE TABLE public."order" ( id uuid NOT NULL DEFAULT gen_random_uuid(), number character varying NOT NULL, user_id uuid NOT NULL DEFAULT gen_random_uuid(), order_status_code character varying NOT NULL, user_full_name character varying NOT NULL, address character varying NOT NULL, phone_number character varying NOT NULL, notes character varying, total numeric NOT NULL, expired_at timestamp with time zone NOT NULL, created_at timestamp with time zone NOT NULL DEFAULT now(), created_by character varying NOT NULL, updated_at timestamp with time zone, updated_by character varying, deleted_at timestamp with time zone, deleted_by character varying, is_deleted boolean DEFAULT false, payment_provider character varying, payment_invoice_id character varying, payment_invoice_url character varying, paid_at timestamp with time zone, payment_method character varying, payment_channel character varying, refunded_total numeric NOT NULL DEFAULT 0, CONSTRAINT order_pkey PRIMARY KEY (id), CONSTRAINT order_user_id_fkey FOREIGN KEY (user_id) REFERENCES public."user"(id), CONSTRAINT order_order_status_code_fkey FOREIGN KEY (order_status_code) REFERENCES public.order_status(code) );
+
+CREATE TABLE public.order_item ( id bigint GENERATED ALWAYS AS IDENTITY NOT NULL, product_id uuid NOT NULL DEFAULT gen_random_uuid(), product_name character varying NOT NULL, product_image_file_name character varying NOT NULL, product_price numeric NOT NULL, quantity bigint NOT NULL, refunded_quantity bigint NOT NULL DEFAULT 0, order_id uuid, created_at timestamp with time zone NOT NULL DEFAULT now(), created_by character varying NOT NULL, updated_at timestamp with time zone, updated_by character varying, deleted_at timestamp with time zone, deleted_by character varying, is_deleted boolean DEFAULT false, CONSTRAINT order_item_pkey PRIMARY KEY (id), CONSTRAINT order_item_product_id_fkey FOREIGN KEY (product_id) REFERENCES public.product(id), CONSTRAINT order_item_order_id_fkey FOREIGN KEY (order_id) REFERENCES public."order"(id), CONSTRAINT order_item_refunded_quantity_check CHECK (refunded_quantity >= 0 AND refunded_quantity <= quantity) );
+
+CREATE TABLE public.user_cart ( id bigint GENERATED ALWAYS AS IDENTITY NOT NULL, product_id uuid NOT NULL DEFAULT gen_random_uuid(), user_id uuid NOT NULL DEFAULT gen_random_uuid(), quantity bigint NOT NULL DEFAULT '0'::bigint, created_at timestamp with time zone NOT NULL DEFAULT now(), created_by character varying NOT NULL DEFAULT ''::character varying, updated_at timestamp with time zone, updated_by character varying, CONSTRAINT user_cart_pkey PRIMARY KEY (id), CONSTRAINT user_cart_product_id_fkey FOREIGN KEY (product_id) REFERENCES public.product(id), CONSTRAINT user_cart_user_id_fkey FOREIGN KEY (user_id) REFERENCES public."user"(id) );
+
+CREATE TABLE public.payment_webhook_event ( id uuid NOT NULL DEFAULT gen_random_uuid(), provider character varying NOT NULL, invoice_id character varying NOT NULL, order_id character varying NOT NULL, status character varying NOT NULL, amount numeric NOT NULL, payload text NOT NULL, result character varying NOT NULL, created_at timestamp with time zone NOT NULL DEFAULT now(), updated_at timestamp with time zone, CONSTRAINT payment_webhook_event_pkey PRIMARY KEY (id), CONSTRAINT payment_webhook_event_invoice_status_key UNIQUE (provider, invoice_id, status) );
+CREATE TABLE public.order_refund ( id uuid NOT NULL DEFAULT gen_random_uuid(), order_id uuid NOT NULL, amount numeric NOT NULL, reason character varying NOT NULL, restock boolean NOT NULL DEFAULT false, status character varying NOT NULL DEFAULT 'completed'::character varying, payment_provider character varying, payment_refund_id character varying, created_at timestamp with time zone NOT NULL DEFAULT now(), created_by character varying NOT NULL, updated_at timestamp with time zone, CONSTRAINT order_refund_pkey PRIMARY KEY (id), CONSTRAINT order_refund_order_id_fkey FOREIGN KEY (order_id) REFERENCES public."order"(id) );
+CREATE TABLE public.order_refund_item ( id uuid NOT NULL DEFAULT gen_random_uuid(), order_refund_id uuid NOT NULL, product_id uuid NOT NULL, quantity bigint NOT NULL, amount numeric NOT NULL, created_at timestamp with time zone NOT NULL DEFAULT now(), CONSTRAINT order_refund_item_pkey PRIMARY KEY (id), CONSTRAINT order_refund_item_order_refund_id_fkey FOREIGN KEY (order_refund_id) REFERENCES public.order_refund(id), CONSTRAINT order_refund_item_product_id_fkey FOREIGN KEY (product_id) REFERENCES public.product(id), CONSTRAINT order_refund_item_quantity_check CHECK (quantity > 0) );
+CREATE INDEX order_refund_order_id_status_idx ON public.order_refund (order_id, status);
+INSERT INTO public.order_status (name, code, created_by) VALUES ('Refunded', 'refunded', 'System'), ('Partially Refunded', 'partially_refunded', 'System') ON CONFLICT (code) DO NOTHING;
+CREATE TABLE public.order_status_history ( id uuid NOT NULL DEFAULT gen_random_uuid(), order_id uuid NOT NULL, from_status_code character varying, to_status_code character varying NOT NULL, actor character varying NOT NULL, actor_role character varying NOT NULL, reason character varying NOT NULL, created_at timestamp with time zone NOT NULL DEFAULT now(), CONSTRAINT order_status_history_pkey PRIMARY KEY (id), CONSTRAINT order_status_history_order_id_fkey FOREIGN KEY (order_id) REFERENCES public."order"(id), CONSTRAINT order_status_history_from_status_code_fkey FOREIGN KEY (from_status_code) REFERENCES public.order_status(code), CONSTRAINT order_status_history_to_status_code_fkey FOREIGN KEY (to_status_code) REFERENCES public.order_status(code) );
+CREATE TABLE public.permission ( id uuid NOT NULL DEFAULT gen_random_uuid(), code character varying NOT NULL UNIQUE, name character varying NOT NULL, created_at timestamp with time zone NOT NULL DEFAULT now(), created_by character varying NOT NULL, updated_at timestamp with time zone, updated_by character varying, deleted_at timestamp with time zone, deleted_by character varying, is_deleted boolean DEFAULT false, CONSTRAINT permission_pkey PRIMARY KEY (id) );
+CREATE TABLE public.role_permission ( id uuid NOT NULL DEFAULT gen_random_uuid(), role_code character varying NOT NULL, permission_code character varying NOT NULL, created_at timestamp with time zone NOT NULL DEFAULT now(), created_by character varying NOT NULL, updated_at timestamp with time zone, updated_by character varying, deleted_at timestamp with time zone, deleted_by character varying, is_deleted boolean DEFAULT false, CONSTRAINT role_permission_pkey PRIMARY KEY (id), CONSTRAINT role_permission_role_code_permission_code_key UNIQUE (role_code, permission_code), CONSTRAINT role_permission_role_code_fkey FOREIGN KEY (role_code) REFERENCES public.user_role(code), CONSTRAINT role_permission_permission_code_fkey FOREIGN KEY (permission_code) REFERENCES public.permission(code) );
+INSERT INTO public.permission (code, name, created_by) VALUES ('product.manage', 'Manage products', 'System'), ('order.read_all', 'Read orders of every user', 'System'), ('order.manage', 'Manage order status', 'System'), ('order.refund', 'Refund orders', 'System'), ('user.manage', 'Manage user accounts', 'System'), ('service_account.manage', 'Manage service accounts and API keys', 'System') ON CONFLICT (code) DO NOTHING;
+INSERT INTO public.role_permission (role_code, permission_code, created_by) SELECT 'admin', code, 'System' FROM public.permission ON CONFLICT (role_code, permission_code) DO NOTHING;
+CREATE TABLE public.refresh_token ( id uuid NOT NULL DEFAULT gen_random_uuid(), user_id uuid NOT NULL, family_id uuid NOT NULL, token_hash character varying NOT NULL UNIQUE, expires_at timestamp with time zone NOT NULL, created_at timestamp with time zone NOT NULL DEFAULT now(), used_at timestamp with time zone, replaced_by uuid, revoked_at timestamp with time zone, CONSTRAINT refresh_token_pkey PRIMARY KEY (id), CONSTRAINT refresh_token_user_id_fkey FOREIGN KEY (user_id) REFERENCES public."user"(id) );
+CREATE INDEX refresh_token_family_id_idx ON public.refresh_token (family_id);
+CREATE TABLE public.revoked_token ( jti character varying NOT NULL, expires_at timestamp with time zone NOT NULL, revoked_at timestamp with time zone NOT NULL DEFAULT now(), CONSTRAINT revoked_token_pkey PRIMARY KEY (jti) );
+CREATE INDEX revoked_token_expires_at_idx ON public.revoked_token (expires_at);
+CREATE TABLE public.one_time_token ( id uuid NOT NULL DEFAULT gen_random_uuid(), user_id uuid NOT NULL, purpose character varying NOT NULL, token_hash character varying NOT NULL UNIQUE, new_email character varying, expires_at timestamp with time zone NOT NULL, created_at timestamp with time zone NOT NULL DEFAULT now(), used_at timestamp with time zone, CONSTRAINT one_time_token_pkey PRIMARY KEY (id), CONSTRAINT one_time_token_user_id_fkey FOREIGN KEY (user_id) REFERENCES public."user"(id) );
+CREATE INDEX one_time_token_user_id_purpose_idx ON public.one_time_token (user_id, purpose);
+CREATE TABLE public.login_attempt ( key character varying NOT NULL, failed_count integer NOT NULL DEFAULT 0, last_failed_at timestamp with time zone NOT NULL DEFAULT now(), locked_until timestamp with time zone, CONSTRAINT login_attempt_pkey PRIMARY KEY (key) );
+CREATE TABLE public.totp_recovery_code ( id uuid NOT NULL DEFAULT gen_random_uuid(), user_id uuid NOT NULL, code_hash character varying NOT NULL, created_at timestamp with time zone NOT NULL DEFAULT now(), used_at timestamp with time zone, CONSTRAINT totp_recovery_code_pkey PRIMARY KEY (id), CONSTRAINT totp_recovery_code_user_id_fkey FOREIGN KEY (user_id) REFERENCES public."user"(id) );
+CREATE TABLE public.login_challenge ( id uuid NOT NULL DEFAULT gen_random_uuid(), user_id uuid NOT NULL, token_hash character varying NOT NULL UNIQUE, expires_at timestamp with time zone NOT NULL, created_at timestamp with time zone NOT NULL DEFAULT now(), used_at timestamp with time zone, failed_count integer NOT NULL DEFAULT 0, CONSTRAINT login_challenge_pkey PRIMARY KEY (id), CONSTRAINT login_challenge_user_id_fkey FOREIGN KEY (user_id) REFERENCES public."user"(id) );
+CREATE TABLE public.user_identity ( id uuid NOT NULL DEFAULT gen_random_uuid(), user_id uuid NOT NULL, provider character varying NOT NULL, subject character varying NOT NULL, email character varying NOT NULL DEFAULT ''::character varying, created_at timestamp with time zone NOT NULL DEFAULT now(), CONSTRAINT user_identity_pkey PRIMARY KEY (id), CONSTRAINT user_identity_provider_subject_key UNIQUE (provider, subject), CONSTRAINT user_identity_user_id_fkey FOREIGN KEY (user_id) REFERENCES public."user"(id) );
+CREATE TABLE public.oidc_login_state ( id uuid NOT NULL DEFAULT gen_random_uuid(), state_hash character varying NOT NULL UNIQUE, provider character varying NOT NULL, nonce character varying NOT NULL, code_verifier character varying NOT NULL, expires_at timestamp with time zone NOT NULL, created_at timestamp with time zone NOT NULL DEFAULT now(), used_at timestamp with time zone, CONSTRAINT oidc_login_state_pkey PRIMARY KEY (id) );
+CREATE TABLE public.service_account ( id uuid NOT NULL DEFAULT gen_random_uuid(), name character varying NOT NULL, role_code character varying NOT NULL, created_at timestamp with time zone NOT NULL DEFAULT now(), created_by character varying NOT NULL, CONSTRAINT service_account_pkey PRIMARY KEY (id), CONSTRAINT service_account_role_code_fkey FOREIGN KEY (role_code) REFERENCES public.user_role(code) );
+CREATE TABLE public.api_key ( id uuid NOT NULL DEFAULT gen_random_uuid(), service_account_id uuid NOT NULL, name character varying NOT NULL, prefix character varying NOT NULL, key_hash character varying NOT NULL UNIQUE, scopes character varying[] NOT NULL, created_at timestamp with time zone NOT NULL DEFAULT now(), created_by character varying NOT NULL, expires_at timestamp with time zone, last_used_at timestamp with time zone, revoked_at timestamp with time zone, CONSTRAINT api_key_pkey PRIMARY KEY (id), CONSTRAINT api_key_service_account_id_fkey FOREIGN KEY (service_account_id) REFERENCES public.service_account(id) );
+CREATE TABLE public.user_session ( id uuid NOT NULL, user_id uuid NOT NULL, user_agent character varying NOT NULL, ip_address character varying NOT NULL, created_at timestamp with time zone NOT NULL DEFAULT now(), last_seen_at timestamp with time zone NOT NULL DEFAULT now(), expires_at timestamp with time zone NOT NULL, revoked_at timestamp with time zone, CONSTRAINT user_session_pkey PRIMARY KEY (id), CONSTRAINT user_session_user_id_fkey FOREIGN KEY (user_id) REFERENCES public."user"(id) );
\ No newline at end of file